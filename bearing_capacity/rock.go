@@ -0,0 +1,165 @@
+package bearing_capacity
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// RockBearingCapacity is a struct that contains the bearing capacity of a foundation on rock
+type RockBearingCapacity struct {
+	Method                   string  `json:"method"`
+	UltimateBearingPressure  float64 `json:"ultimate_bearing_pressure"`
+	AllowableBearingPressure float64 `json:"allowable_bearing_pressure"`
+}
+
+// HoekBrownParameters is a struct that contains the generalized Hoek-Brown constants of a rock mass
+type HoekBrownParameters struct {
+	Mb float64 `json:"mb"`
+	S  float64 `json:"s"`
+	A  float64 `json:"a"`
+}
+
+// Is50ToUCS is the ratio of uniaxial compressive strength to point load index (Broch and Franklin, 1972)
+const Is50ToUCS = 24.0
+
+// tsfToKPa converts tons per square foot to kPa
+const tsfToKPa = 95.76
+
+// GetBearingLayerIndex returns the index of the layer that the foundation base sits on
+func GetBearingLayerIndex(sp ds.SoilProfile, Df float64) int {
+	// a base lying exactly on a layer boundary bears on the lower layer
+	return sp.GetLayerIndex(Df + 1e-6)
+}
+
+// getRockLayerIndex returns the index of the layer that the foundation base sits on and whether it is a rock layer
+// for which each of the given properties is given
+func getRockLayerIndex(sp ds.SoilProfile, Df float64, fields ...[]float64) (int, bool) {
+	layerIndex := GetBearingLayerIndex(sp, Df)
+	if !sp.IsRock(Df + 1e-6) {
+		return layerIndex, false
+	}
+	for _, field := range fields {
+		if len(field) <= layerIndex {
+			return layerIndex, false
+		}
+	}
+	return layerIndex, true
+}
+
+// getUndefinedCapacity returns the bearing capacity of a method that can not be applied, which is NaN
+func getUndefinedCapacity(method string) RockBearingCapacity {
+	return RockBearingCapacity{
+		Method:                   method,
+		UltimateBearingPressure:  math.NaN(),
+		AllowableBearingPressure: math.NaN(),
+	}
+}
+
+// CalcUCS returns the uniaxial compressive strength of the rock layer with the given index, or NaN if the point
+// load index of the layer is not given
+func CalcUCS(sp ds.SoilProfile, layerIndex int) float64 {
+	if len(sp.IS50) <= layerIndex {
+		return math.NaN()
+	}
+	return Is50ToUCS * sp.IS50[layerIndex]
+}
+
+// CalcHoekBrownParameters returns the rock mass constants of the generalized Hoek-Brown criterion (Hoek et al., 2002)
+func CalcHoekBrownParameters(GSI, mi, D float64) HoekBrownParameters {
+	mb := mi * math.Exp((GSI-100)/(28-14*D))
+	s := math.Exp((GSI - 100) / (9 - 3*D))
+	a := 0.5 + (math.Exp(-GSI/15)-math.Exp(-20.0/3))/6
+	return HoekBrownParameters{Mb: mb, S: s, A: a}
+}
+
+// calcMajorPrincipalStress returns the major principal stress at failure for the given minor principal stress
+func (hb HoekBrownParameters) calcMajorPrincipalStress(sigma3, UCS float64) float64 {
+	return sigma3 + UCS*math.Pow(hb.Mb*sigma3/UCS+hb.S, hb.A)
+}
+
+// CalcHoekBrown returns the bearing capacity of a strip on a rock mass from the two wedge Hoek-Brown solution (Wyllie, 1999)
+// D is the disturbance factor of the rock mass beneath the foundation. The capacity is NaN when the foundation does
+// not bear on rock or IS50, GSI or mi of the rock is not given, which also holds for the other rock methods.
+func CalcHoekBrown(sp ds.SoilProfile, bd ds.BuildingData, D, FS float64) RockBearingCapacity {
+	layerIndex, isRock := getRockLayerIndex(sp, bd.Df, sp.IS50, sp.GSI, sp.Mi)
+	if !isRock {
+		return getUndefinedCapacity("Hoek-Brown")
+	}
+	UCS := CalcUCS(sp, layerIndex)
+	hb := CalcHoekBrownParameters(sp.GSI[layerIndex], sp.Mi[layerIndex], D)
+	surcharge := sp.CalcEffectiveStress(bd.Df)
+
+	// the passive wedge confines the active wedge under the foundation
+	passiveStress := hb.calcMajorPrincipalStress(surcharge, UCS)
+	qult := hb.calcMajorPrincipalStress(passiveStress, UCS)
+
+	return RockBearingCapacity{
+		Method:                   "Hoek-Brown",
+		UltimateBearingPressure:  qult,
+		AllowableBearingPressure: qult / FS,
+	}
+}
+
+// CalcCarterKulhawy returns the lower bound bearing capacity of a foundation on jointed rock (Carter and Kulhawy, 1988)
+func CalcCarterKulhawy(sp ds.SoilProfile, bd ds.BuildingData, FS float64) RockBearingCapacity {
+	layerIndex, isRock := getRockLayerIndex(sp, bd.Df, sp.IS50, sp.GSI, sp.Mi)
+	if !isRock {
+		return getUndefinedCapacity("Carter-Kulhawy")
+	}
+	UCS := CalcUCS(sp, layerIndex)
+	hb := CalcHoekBrownParameters(sp.GSI[layerIndex], sp.Mi[layerIndex], 0)
+	qult := (math.Sqrt(hb.S) + math.Sqrt(hb.Mb*math.Sqrt(hb.S)+hb.S)) * UCS
+
+	return RockBearingCapacity{
+		Method:                   "Carter-Kulhawy",
+		UltimateBearingPressure:  qult,
+		AllowableBearingPressure: qult / FS,
+	}
+}
+
+// CalcAASHTO returns the bearing capacity of a foundation on jointed rock from the RQD based allowable contact
+// pressures of AASHTO (Peck et al., 1974). The allowable pressure is limited to the uniaxial compressive strength.
+func CalcAASHTO(sp ds.SoilProfile, bd ds.BuildingData, FS float64) RockBearingCapacity {
+	layerIndex, isRock := getRockLayerIndex(sp, bd.Df, sp.IS50, sp.RQD)
+	if !isRock {
+		return getUndefinedCapacity("AASHTO")
+	}
+	UCS := CalcUCS(sp, layerIndex)
+	RQDs := []float64{0, 25, 50, 75, 90, 100}
+	pressures := []float64{10, 30, 65, 120, 200, 300} // tsf
+
+	qa := ds.ConvertPressure(np.Interp([]float64{sp.RQD[layerIndex]}, RQDs, pressures)[0]*tsfToKPa, "kPa", sp.PressureUnit)
+	qa = math.Min(qa, UCS)
+
+	return RockBearingCapacity{
+		Method:                   "AASHTO",
+		UltimateBearingPressure:  qa * FS,
+		AllowableBearingPressure: qa,
+	}
+}
+
+// CalcCGS returns the bearing capacity of a foundation on sedimentary rock with the discontinuity spacing method
+// of the Canadian Foundation Engineering Manual. The Ksp coefficient already includes a safety factor of 3.
+func CalcCGS(sp ds.SoilProfile, bd ds.BuildingData) RockBearingCapacity {
+	layerIndex, isRock := getRockLayerIndex(sp, bd.Df, sp.IS50, sp.JointSpacing, sp.JointAperture)
+	if !isRock {
+		return getUndefinedCapacity("CGS")
+	}
+	UCS := CalcUCS(sp, layerIndex)
+	Ksp := CalcKsp(sp.JointSpacing[layerIndex], sp.JointAperture[layerIndex], bd.B)
+	qa := Ksp * UCS
+
+	return RockBearingCapacity{
+		Method:                   "CGS",
+		UltimateBearingPressure:  qa * 3,
+		AllowableBearingPressure: qa,
+	}
+}
+
+// CalcKsp returns the empirical coefficient of the CGS method for the given discontinuity spacing, aperture and
+// foundation width
+func CalcKsp(spacing, aperture, B float64) float64 {
+	return (3 + spacing/B) / (10 * math.Sqrt(1+300*aperture/spacing))
+}
@@ -0,0 +1,85 @@
+package bearing_capacity
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var rockProfile = ds.SoilProfile{
+	MaterialType:        []string{"Soil", "Rock"},
+	Thickness:           []float64{2, 8},
+	DryUnitWeight:       []float64{18, 25},
+	SaturatedUnitWeight: []float64{20, 26},
+	RQD:                 []float64{0, 80},
+	IS50:                []float64{0, 2000},
+	GSI:                 []float64{0, 60},
+	Mi:                  []float64{0, 10},
+	JointSpacing:        []float64{0, 0.6},
+	JointAperture:       []float64{0, 0.001},
+	Gwt:                 10,
+	CheckGwt:            true,
+}
+
+var rockFoundation = ds.BuildingData{
+	Df: 2,
+	B:  2,
+	L:  4,
+}
+
+func TestCalcHoekBrownParameters(t *testing.T) {
+	expected := []float64{2.3965, 0.0117, 0.5028}
+	hb := CalcHoekBrownParameters(60, 10, 0)
+	output := np.Round([]float64{hb.Mb, hb.S, hb.A}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestRockBearingCapacity(t *testing.T) {
+	expectedUltimate := []float64{31268.24, 30210.02, 42134.4, 38799.92}
+	expectedAllowable := []float64{10422.75, 10070.01, 14044.8, 12933.31}
+
+	results := []RockBearingCapacity{
+		CalcHoekBrown(rockProfile, rockFoundation, 0, 3),
+		CalcCarterKulhawy(rockProfile, rockFoundation, 3),
+		CalcAASHTO(rockProfile, rockFoundation, 3),
+		CalcCGS(rockProfile, rockFoundation),
+	}
+	var outputUltimate, outputAllowable []float64
+	for _, result := range results {
+		outputUltimate = append(outputUltimate, result.UltimateBearingPressure)
+		outputAllowable = append(outputAllowable, result.AllowableBearingPressure)
+	}
+
+	if reflect.DeepEqual(np.Round(outputUltimate, 2), expectedUltimate) == false {
+		t.Errorf("Expected %v, got %v", expectedUltimate, outputUltimate)
+	}
+	if reflect.DeepEqual(np.Round(outputAllowable, 2), expectedAllowable) == false {
+		t.Errorf("Expected %v, got %v", expectedAllowable, outputAllowable)
+	}
+}
+
+func TestRockBearingCapacity_NotRock(t *testing.T) {
+	soilFoundation := rockFoundation
+	soilFoundation.Df = 1
+	missingGSI := rockProfile
+	missingGSI.GSI = nil
+
+	results := []RockBearingCapacity{
+		CalcHoekBrown(rockProfile, soilFoundation, 0, 3),
+		CalcHoekBrown(missingGSI, rockFoundation, 0, 3),
+		CalcCarterKulhawy(missingGSI, rockFoundation, 3),
+	}
+	for _, result := range results {
+		if !math.IsNaN(result.UltimateBearingPressure) || !math.IsNaN(result.AllowableBearingPressure) {
+			t.Errorf("Expected %v, got %v", math.NaN(), result.UltimateBearingPressure)
+		}
+	}
+	if result := CalcAASHTO(missingGSI, rockFoundation, 3); math.IsNaN(result.AllowableBearingPressure) {
+		t.Errorf("Expected %v, got %v", 14044.8, result.AllowableBearingPressure)
+	}
+}
@@ -0,0 +1,56 @@
+package bearing_capacity
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+)
+
+// CalcMeyerhofFactors returns the bearing capacity factors Nc, Nq and Ngamma of Meyerhof (1963) for the given
+// friction angle in degrees
+func CalcMeyerhofFactors(phi float64) (float64, float64, float64) {
+	if phi == 0 {
+		return 5.14, 1, 0
+	}
+	phiRad := phi * math.Pi / 180
+	Nq := math.Exp(math.Pi*math.Tan(phiRad)) * math.Pow(math.Tan(math.Pi/4+phiRad/2), 2)
+	Nc := (Nq - 1) / math.Tan(phiRad)
+	Ngamma := (Nq - 1) * math.Tan(1.4*phiRad)
+	return Nc, Nq, Ngamma
+}
+
+// CalcMeyerhofBearingCapacity returns the ultimate bearing pressure of a foundation of width B and length L at
+// depth Df on the soil profile by Meyerhof (1963), for a load inclined at the given angle in degrees from the
+// vertical. A length of zero is taken as a strip foundation.
+func CalcMeyerhofBearingCapacity(sp ds.SoilProfile, B, L, Df, inclination float64) float64 {
+	layerIndex := GetBearingLayerIndex(sp, Df)
+	phi := sp.Phi[layerIndex]
+	var c float64
+	if len(sp.Cohesion) > layerIndex {
+		c = sp.Cohesion[layerIndex]
+	}
+	Nc, Nq, Ngamma := CalcMeyerhofFactors(phi)
+	Kp := math.Pow(math.Tan((45+phi/2)*math.Pi/180), 2)
+
+	ratio := 0.0
+	if L > 0 {
+		ratio = B / L
+	}
+	sc := 1 + 0.2*Kp*ratio
+	dc := 1 + 0.2*math.Sqrt(Kp)*Df/B
+	sq, dq := 1.0, 1.0
+	if phi > 10 {
+		sq = 1 + 0.1*Kp*ratio
+		dq = 1 + 0.1*math.Sqrt(Kp)*Df/B
+	}
+
+	ic := math.Pow(1-inclination/90, 2)
+	var igamma float64
+	if phi > 0 && inclination < phi {
+		igamma = math.Pow(1-inclination/phi, 2)
+	}
+
+	q := sp.CalcEffectiveStress(Df)
+	gamma := (sp.CalcEffectiveStress(Df+B) - q) / B
+	return c*Nc*sc*dc*ic + q*Nq*sq*dq*ic + 0.5*gamma*B*Ngamma*sq*dq*igamma
+}
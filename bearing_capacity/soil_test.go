@@ -0,0 +1,36 @@
+package bearing_capacity
+
+import (
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+func TestCalcMeyerhofFactors(t *testing.T) {
+	Nc, Nq, Ngamma := CalcMeyerhofFactors(30)
+	expected := []float64{30.14, 18.4, 15.67}
+	output := np.Round([]float64{Nc, Nq, Ngamma}, 2)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcMeyerhofBearingCapacity(t *testing.T) {
+	clay := ds.SoilProfile{
+		Thickness:           []float64{10},
+		DryUnitWeight:       []float64{18},
+		SaturatedUnitWeight: []float64{18},
+		Phi:                 []float64{0},
+		Cohesion:            []float64{50},
+		Gwt:                 10,
+		CheckGwt:            true,
+	}
+	// strip footing on the surface: qult = 5.14c
+	expected := 257.0
+	output := np.RoundFloat(CalcMeyerhofBearingCapacity(clay, 2, 0, 0, 0), 4)
+	if expected != output {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
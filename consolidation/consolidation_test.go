@@ -0,0 +1,184 @@
+package consolidation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var clayProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL"},
+	Thickness:           []float64{10},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{19},
+	Mv:                  []float64{0.001},
+	Cv:                  []float64{2},
+	Gwt:                 0,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var layeredProfile = ds.SoilProfile{
+	SoilClass:           []string{"CH", "SP", "CL"},
+	Thickness:           []float64{4, 2, 6},
+	DryUnitWeight:       []float64{17, 19, 18},
+	SaturatedUnitWeight: []float64{18, 20, 19},
+	Cc:                  []float64{0.4, 0, 0.3},
+	VoidRatio:           []float64{1.2, 0.6, 1},
+	Mv:                  []float64{0, 0.0002, 0},
+	Cv:                  []float64{1, 0, 3},
+	Gwt:                 1,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var times = []float64{0.5, 2, 5, 10}
+
+func TestTerzaghiSolution(t *testing.T) {
+	expected := []float64{0.1963, 0.848, 0.4468, 0.4995}
+	output := np.Round([]float64{
+		CalcTimeFactorForDegree(0.5),
+		CalcTimeFactorForDegree(0.9),
+		CalcLocalDegree(0.2, 0.5),
+		CalcAverageDegree(math.Pi / 4 * 0.25),
+	}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcLayerConsolidation(t *testing.T) {
+	expected := []float64{0.2257, 0.4512, 0.6979, 0.8874}
+	output := np.Round(CalcLayerConsolidation(clayProfile, 0, "double", 100, times).Degree, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	expected = []float64{0.1128, 0.2257, 0.3568, 0.5041}
+	output = np.Round(CalcLayerConsolidation(clayProfile, 0, "single", 100, times).Degree, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcMultilayerConsolidation(t *testing.T) {
+	// the finite difference solution of a single layer agrees with the Terzaghi solution
+	expected := []float64{0.225, 0.451, 0.698, 0.887}
+	output := np.Round(CalcMultilayerConsolidation(clayProfile, 0, 10, "double", 100, times).Degree, 3)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	curve := CalcMultilayerConsolidation(layeredProfile, 0, 12, "single", 50, times)
+	expected = []float64{0.2772, 0.4589, 0.5627, 0.5979, 0.6091}
+	output = np.Round(append(curve.Settlement, curve.FinalSettlement), 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcMultilayerConsolidation_NoLoad(t *testing.T) {
+	expected := []float64{0, 0, 0, 0, 1, 1, 1, 1}
+	curve := CalcMultilayerConsolidation(layeredProfile, 0, 12, "single", 0, times)
+	output := append(curve.Settlement, curve.Degree...)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+var softClayProfile = ds.SoilProfile{
+	SoilClass:           []string{"CH"},
+	Thickness:           []float64{12},
+	DryUnitWeight:       []float64{16},
+	SaturatedUnitWeight: []float64{16},
+	Cc:                  []float64{0.6},
+	VoidRatio:           []float64{1.5},
+	Cv:                  []float64{1},
+	Permeability:        []float64{0.03},
+	Gwt:                 0,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var bandDrain = ds.DrainData{
+	Pattern:           "triangular",
+	Spacing:           1.5,
+	Width:             0.1,
+	Thickness:         0.004,
+	SmearRatio:        2,
+	PermeabilityRatio: 2,
+	Discharge:         100,
+	Length:            12,
+	ChRatio:           2,
+}
+
+func TestDrainFactors(t *testing.T) {
+	expected := []float64{0.052, 1.575, 2.6553, 3.5575}
+	output := np.Round([]float64{
+		CalcEquivalentDrainDiameter(bandDrain),
+		CalcInfluenceDiameter(bandDrain),
+		CalcBarronF(30),
+		CalcHansboMu(bandDrain, 0.06, 6),
+	}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcDrainDegree(t *testing.T) {
+	expected := []float64{0.6229, 0.0665}
+	output := np.Round([]float64{
+		CalcDrainDegree(softClayProfile, 0, bandDrain, "single", 0.5),
+		CalcDrainDegree(softClayProfile, 0, ds.DrainData{}, "single", 0.5),
+	}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestPreloadingDesign(t *testing.T) {
+	expected := []float64{1.0131, 5.2019}
+	output := np.Round([]float64{
+		CalcRequiredDrainSpacing(softClayProfile, 0, bandDrain, "single", 0.9, 0.5),
+		CalcRequiredSurcharge(softClayProfile, 0, bandDrain, "single", 60, 0.5, 20),
+	}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	// the height does not depend on the pressure unit
+	sp := softClayProfile.ConvertUnits("kN/m3", "t/m2")
+	height := np.RoundFloat(CalcRequiredSurcharge(sp, 0, bandDrain, "single", ds.ConvertPressure(60, "kPa", "t/m2"), 0.5, 20), 4)
+	if height != expected[1] {
+		t.Errorf("Expected %v, got %v", expected[1], height)
+	}
+
+	// the surcharge is not sufficient without drains
+	surcharge := CalcRequiredSurcharge(softClayProfile, 0, ds.DrainData{}, "single", 60, 0.5, 20)
+	if !math.IsNaN(surcharge) {
+		t.Errorf("Expected %v, got %v", math.NaN(), surcharge)
+	}
+}
+
+func TestCalcSecondaryCompression(t *testing.T) {
+	sp := softClayProfile
+	sp.Cv = []float64{5}
+	sp.CalphaRatio = []float64{0.04}
+
+	expected := []float64{0.024, 12.8232, 0, 0.079}
+	output := np.Round([]float64{
+		GetCalpha(sp, 0),
+		CalcEndOfPrimaryTime(sp, 0, "double"),
+		CalcSecondaryCompression(sp, 0, 12, "double", 60, 10),
+		CalcSecondaryCompression(sp, 0, 12, "double", 60, 50),
+	}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
@@ -0,0 +1,141 @@
+package consolidation
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// StabilityFactor is the ratio of the time step of the explicit finite difference solution to its stability limit
+const StabilityFactor = 0.4
+
+// cell is a sublayer of the finite difference solution
+type cell struct {
+	Center      float64
+	Thickness   float64
+	Compression float64 // final consolidation settlement
+	Mv          float64
+	Diffusivity float64 // permeability divided by the unit weight of water
+	Drained     bool
+}
+
+// getCells returns the sublayers of the soil profile between the given levels. The layers which are not cohesive or
+// do not have a coefficient of consolidation drain freely, and the coefficient of volume compressibility of each
+// sublayer is derived from its consolidation settlement so that the layers compressed by Cc are also handled.
+func getCells(sp ds.SoilProfile, top, bottom, dSigma float64) []cell {
+	centers, thicknesses := settlement.GetSublayers(sp, top, bottom, settlement.SublayerThickness)
+	gammaW := sp.WaterUnitWeight()
+
+	var cells []cell
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		compression := settlement.CalcSublayerConsolidation(sp, center, thicknesses[i], dSigma)
+		var mv float64
+		if dSigma > 0 {
+			mv = compression / (thicknesses[i] * dSigma)
+		}
+		cv := GetCv(sp, layerIndex)
+
+		diffusivity := cv * mv
+		if len(sp.Cv) <= layerIndex || sp.Cv[layerIndex] <= 0 {
+			if len(sp.Permeability) > layerIndex {
+				diffusivity = sp.Permeability[layerIndex] / gammaW
+			}
+		}
+
+		cells = append(cells, cell{
+			Center:      center,
+			Thickness:   thicknesses[i],
+			Compression: compression,
+			Mv:          mv,
+			Diffusivity: diffusivity,
+			Drained:     !sp.IsCohesive(center) || mv <= 0 || diffusivity <= 0,
+		})
+	}
+	return cells
+}
+
+// calcConductances returns the hydraulic conductances between consecutive cells and from the first and last cells
+// to the drainage boundaries at the top and bottom, where an impermeable bottom has zero conductance
+func calcConductances(cells []cell, drainage string) []float64 {
+	halfResistance := func(c cell) float64 {
+		if c.Drained {
+			return 0
+		}
+		return c.Thickness / (2 * c.Diffusivity)
+	}
+
+	conductances := []float64{1 / halfResistance(cells[0])}
+	for i := 1; i < len(cells); i++ {
+		conductances = append(conductances, 1/(halfResistance(cells[i-1])+halfResistance(cells[i])))
+	}
+	if drainage == "double" {
+		conductances = append(conductances, 1/halfResistance(cells[len(cells)-1]))
+	} else {
+		conductances = append(conductances, 0)
+	}
+	return conductances
+}
+
+// CalcMultilayerConsolidation returns the settlement with time of the soil profile between the given levels by
+// the explicit finite difference solution of the one-dimensional consolidation equation across the layers. The top
+// is drained, the bottom is drained for double drainage and the layers which are not cohesive drain freely and
+// settle immediately. The excess pore pressures are equal to the stress increase at the start. The degree of
+// consolidation is 1 when there is no settlement to occur.
+func CalcMultilayerConsolidation(sp ds.SoilProfile, top, bottom float64, drainage string, dSigma float64, times []float64) ConsolidationCurve {
+	cells := getCells(sp, top, bottom, dSigma)
+	conductances := calcConductances(cells, drainage)
+
+	var final float64
+	u := make([]float64, len(cells))
+	dt := math.Inf(1)
+	for i, c := range cells {
+		final += c.Compression
+		if c.Drained {
+			continue
+		}
+		u[i] = dSigma
+		// cells next to a freely draining cell or boundary have their whole conductance towards it
+		dt = math.Min(dt, StabilityFactor*c.Mv*c.Thickness/(conductances[i]+conductances[i+1]))
+	}
+
+	curve := ConsolidationCurve{Time: times, FinalSettlement: final}
+	var t float64
+	for _, time := range times {
+		for t < time {
+			step := math.Min(dt, time-t)
+			next := make([]float64, len(u))
+			for i, c := range cells {
+				if c.Drained {
+					continue
+				}
+				var above, below float64
+				if i > 0 {
+					above = u[i-1]
+				}
+				if i < len(cells)-1 {
+					below = u[i+1]
+				}
+				flow := conductances[i]*(above-u[i]) + conductances[i+1]*(below-u[i])
+				next[i] = u[i] + step*flow/(c.Mv*c.Thickness)
+			}
+			u = next
+			t += step
+		}
+
+		s := final
+		for i, c := range cells {
+			if !c.Drained {
+				s -= c.Compression * u[i] / dSigma
+			}
+		}
+		degree := 1.0
+		if final > 0 {
+			degree = s / final
+		}
+		curve.Settlement = append(curve.Settlement, s)
+		curve.Degree = append(curve.Degree, degree)
+	}
+	return curve
+}
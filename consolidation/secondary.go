@@ -0,0 +1,57 @@
+package consolidation
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// EndOfPrimaryDegree is the average degree of consolidation taken as the end of primary consolidation
+const EndOfPrimaryDegree = 0.99
+
+// GetCalpha returns the secondary compression index of the layer with the given index, which is calculated from
+// the ratio of Calpha to Cc when it is not given
+func GetCalpha(sp ds.SoilProfile, layerIndex int) float64 {
+	if len(sp.Calpha) > layerIndex && sp.Calpha[layerIndex] > 0 {
+		return sp.Calpha[layerIndex]
+	}
+	if len(sp.CalphaRatio) > layerIndex && len(sp.Cc) > layerIndex {
+		return sp.CalphaRatio[layerIndex] * sp.Cc[layerIndex]
+	}
+	return 0
+}
+
+// CalcEndOfPrimaryTime returns the time at the end of primary consolidation of the layer with the given index for
+// single or double drainage
+func CalcEndOfPrimaryTime(sp ds.SoilProfile, layerIndex int, drainage string) float64 {
+	Hdr := GetDrainagePath(sp.Thickness[layerIndex], drainage)
+	return CalcTimeFactorForDegree(EndOfPrimaryDegree) * math.Pow(Hdr, 2) / GetCv(sp, layerIndex)
+}
+
+// CalcSecondaryCompression returns the secondary compression settlement of the soil profile between the given
+// levels at time t after the application of a uniform stress increase over a wide area. The void ratio at the end
+// of primary consolidation is reduced by the primary consolidation of each sublayer, and the layers without a
+// secondary compression index or a coefficient of consolidation do not creep.
+func CalcSecondaryCompression(sp ds.SoilProfile, top, bottom float64, drainage string, dSigma, t float64) float64 {
+	centers, thicknesses := settlement.GetSublayers(sp, top, bottom, settlement.SublayerThickness)
+
+	var s float64
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		Calpha := GetCalpha(sp, layerIndex)
+		if Calpha <= 0 || GetCv(sp, layerIndex) <= 0 || len(sp.VoidRatio) <= layerIndex {
+			continue
+		}
+		tp := CalcEndOfPrimaryTime(sp, layerIndex, drainage)
+		if t <= tp {
+			continue
+		}
+
+		e0 := sp.VoidRatio[layerIndex]
+		strain := settlement.CalcSublayerConsolidation(sp, center, thicknesses[i], dSigma) / thicknesses[i]
+		ep := e0 - (1+e0)*strain
+		s += Calpha / (1 + ep) * thicknesses[i] * math.Log10(t/tp)
+	}
+	return s
+}
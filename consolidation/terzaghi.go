@@ -0,0 +1,109 @@
+package consolidation
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// SeriesTerms is the number of terms of the series solutions of the consolidation equation
+const SeriesTerms = 100
+
+// ConsolidationCurve is a struct that contains the settlement of a soil profile with time under a uniform stress
+// increase over a wide area. Time is in the units of the coefficient of consolidation.
+type ConsolidationCurve struct {
+	Time            []float64 `json:"time"`
+	Degree          []float64 `json:"degree"`
+	Settlement      []float64 `json:"settlement"`
+	FinalSettlement float64   `json:"final_settlement"`
+}
+
+// GetCv returns the coefficient of consolidation of the layer with the given index, which is calculated from the
+// permeability and the coefficient of volume compressibility when it is not given
+func GetCv(sp ds.SoilProfile, layerIndex int) float64 {
+	if len(sp.Cv) > layerIndex && sp.Cv[layerIndex] > 0 {
+		return sp.Cv[layerIndex]
+	}
+	if len(sp.Permeability) > layerIndex && len(sp.Mv) > layerIndex && sp.Mv[layerIndex] > 0 {
+		return sp.Permeability[layerIndex] / (sp.Mv[layerIndex] * sp.WaterUnitWeight())
+	}
+	return 0
+}
+
+// GetDrainagePath returns the length of the drainage path of a layer of thickness H for single or double drainage
+func GetDrainagePath(H float64, drainage string) float64 {
+	if drainage == "double" {
+		return H / 2
+	}
+	return H
+}
+
+// CalcTimeFactor returns the time factor for the coefficient of consolidation, time and drainage path length
+func CalcTimeFactor(cv, t, Hdr float64) float64 {
+	return cv * t / math.Pow(Hdr, 2)
+}
+
+// CalcAverageDegree returns the average degree of consolidation of a layer with uniform initial excess pore
+// pressure for the time factor (Terzaghi, 1925)
+func CalcAverageDegree(Tv float64) float64 {
+	if Tv <= 0 {
+		return 0
+	}
+	U := 1.0
+	for m := 0; m < SeriesTerms; m++ {
+		M := math.Pi * (2*float64(m) + 1) / 2
+		U -= 2 / math.Pow(M, 2) * math.Exp(-math.Pow(M, 2)*Tv)
+	}
+	return math.Max(U, 0)
+}
+
+// CalcLocalDegree returns the degree of consolidation at the depth ratio z/Hdr measured from the drainage boundary
+// for the time factor
+func CalcLocalDegree(Tv, depthRatio float64) float64 {
+	if Tv <= 0 {
+		return 0
+	}
+	u := 0.0
+	for m := 0; m < SeriesTerms; m++ {
+		M := math.Pi * (2*float64(m) + 1) / 2
+		u += 2 / M * math.Sin(M*depthRatio) * math.Exp(-math.Pow(M, 2)*Tv)
+	}
+	return 1 - u
+}
+
+// CalcTimeFactorForDegree returns the time factor required for the average degree of consolidation
+func CalcTimeFactorForDegree(U float64) float64 {
+	if U <= 0.6 {
+		return math.Pi / 4 * math.Pow(U, 2)
+	}
+	return 1.781 - 0.933*math.Log10(100*(1-U))
+}
+
+// calcLayerFinalSettlement returns the primary consolidation settlement of the layer with the given index under a
+// uniform stress increase over a wide area
+func calcLayerFinalSettlement(sp ds.SoilProfile, layerIndex int, dSigma float64) float64 {
+	layerDepths := sp.GetLayerDepths()
+	top := layerDepths[layerIndex] - sp.Thickness[layerIndex]
+	centers, thicknesses := settlement.GetSublayers(sp, top, layerDepths[layerIndex], settlement.SublayerThickness)
+	var s float64
+	for i, center := range centers {
+		s += settlement.CalcSublayerConsolidation(sp, center, thicknesses[i], dSigma)
+	}
+	return s
+}
+
+// CalcLayerConsolidation returns the settlement of the layer with the given index with time by the Terzaghi
+// one-dimensional solution for single drainage from the top or double drainage
+func CalcLayerConsolidation(sp ds.SoilProfile, layerIndex int, drainage string, dSigma float64, times []float64) ConsolidationCurve {
+	final := calcLayerFinalSettlement(sp, layerIndex, dSigma)
+	cv := GetCv(sp, layerIndex)
+	Hdr := GetDrainagePath(sp.Thickness[layerIndex], drainage)
+	curve := ConsolidationCurve{Time: times, FinalSettlement: final}
+	for _, t := range times {
+		U := CalcAverageDegree(CalcTimeFactor(cv, t, Hdr))
+		curve.Degree = append(curve.Degree, U)
+		curve.Settlement = append(curve.Settlement, U*final)
+	}
+	return curve
+}
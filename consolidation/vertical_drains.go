@@ -0,0 +1,146 @@
+package consolidation
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+)
+
+// MinDrainSpacing and MaxDrainSpacing are the bounds of the drain spacings searched for a target degree of
+// consolidation
+const (
+	MinDrainSpacing = 0.5
+	MaxDrainSpacing = 5.0
+)
+
+// MaxSurchargeRatio is the largest surcharge searched, as a multiple of the fill pressure
+const MaxSurchargeRatio = 5.0
+
+// bisectionIterations is the number of iterations of the bisection searches
+const bisectionIterations = 60
+
+// CalcEquivalentDrainDiameter returns the diameter of a circular drain equivalent to a band drain (Rixner et al.,
+// 1986)
+func CalcEquivalentDrainDiameter(drain ds.DrainData) float64 {
+	return (drain.Width + drain.Thickness) / 2
+}
+
+// CalcInfluenceDiameter returns the diameter of the soil cylinder drained by each drain
+func CalcInfluenceDiameter(drain ds.DrainData) float64 {
+	if drain.Pattern == "square" {
+		return 1.128 * drain.Spacing
+	}
+	return 1.05 * drain.Spacing
+}
+
+// CalcBarronF returns the drain spacing factor of an ideal drain without smear and well resistance for the ratio of
+// the influence diameter to the drain diameter (Barron, 1948)
+func CalcBarronF(n float64) float64 {
+	n2 := math.Pow(n, 2)
+	return n2/(n2-1)*math.Log(n) - (3*n2-1)/(4*n2)
+}
+
+// CalcHansboMu returns the drain factor of Hansbo (1981) including the effects of smear and well resistance at
+// depth z along a drain of length l draining from one end. kh is the horizontal permeability of the soil, and the
+// well resistance is neglected when the discharge capacity is not given.
+func CalcHansboMu(drain ds.DrainData, kh, z float64) float64 {
+	n := CalcInfluenceDiameter(drain) / CalcEquivalentDrainDiameter(drain)
+	s := math.Max(drain.SmearRatio, 1)
+	kRatio := math.Max(drain.PermeabilityRatio, 1)
+
+	mu := math.Log(n/s) + kRatio*math.Log(s) - 0.75
+	if drain.Discharge > 0 {
+		mu += math.Pi * z * (2*drain.Length - z) * kh / drain.Discharge
+	}
+	return mu
+}
+
+// CalcRadialDegree returns the average degree of radial consolidation for the horizontal coefficient of
+// consolidation, time and drain factor
+func CalcRadialDegree(ch, t, de, mu float64) float64 {
+	Th := ch * t / math.Pow(de, 2)
+	return 1 - math.Exp(-8*Th/mu)
+}
+
+// CalcCombinedDegree returns the average degree of consolidation under combined vertical and radial drainage
+// (Carrillo, 1942)
+func CalcCombinedDegree(Uv, Ur float64) float64 {
+	return 1 - (1-Uv)*(1-Ur)
+}
+
+// CalcDrainDegree returns the average degree of consolidation of the layer with the given index at time t with
+// vertical drainage and radial drainage to the drains. The well resistance is evaluated at the middle of the drain
+// and there is no radial drainage when the drain spacing is zero.
+func CalcDrainDegree(sp ds.SoilProfile, layerIndex int, drain ds.DrainData, drainage string, t float64) float64 {
+	cv := GetCv(sp, layerIndex)
+	Uv := CalcAverageDegree(CalcTimeFactor(cv, t, GetDrainagePath(sp.Thickness[layerIndex], drainage)))
+	if drain.Spacing <= 0 {
+		return Uv
+	}
+
+	chRatio := drain.ChRatio
+	if chRatio <= 0 {
+		chRatio = 1
+	}
+	var kh float64
+	if len(sp.Permeability) > layerIndex {
+		kh = chRatio * sp.Permeability[layerIndex]
+	}
+	mu := CalcHansboMu(drain, kh, drain.Length/2)
+	Ur := CalcRadialDegree(chRatio*cv, t, CalcInfluenceDiameter(drain), mu)
+	return CalcCombinedDegree(Uv, Ur)
+}
+
+// CalcRequiredDrainSpacing returns the largest drain spacing that reaches the target degree of consolidation of the
+// layer with the given index at time t. The result is NaN when the target is not reached at MinDrainSpacing.
+func CalcRequiredDrainSpacing(sp ds.SoilProfile, layerIndex int, drain ds.DrainData, drainage string, targetDegree, t float64) float64 {
+	degree := func(spacing float64) float64 {
+		drain.Spacing = spacing
+		return CalcDrainDegree(sp, layerIndex, drain, drainage, t)
+	}
+	low, high := MinDrainSpacing, MaxDrainSpacing
+	if degree(low) < targetDegree {
+		return math.NaN()
+	}
+	if degree(high) >= targetDegree {
+		return high
+	}
+	for i := 0; i < bisectionIterations; i++ {
+		mid := (low + high) / 2
+		if degree(mid) >= targetDegree {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+// CalcRequiredSurcharge returns the height of the surcharge fill of the given unit weight which, placed with the
+// permanent fill, settles the layer with the given index by the final settlement of the permanent fill at time t.
+// The fill pressure and the unit weight are in the units of the soil profile. The result is NaN when a surcharge of
+// MaxSurchargeRatio times the fill pressure is not sufficient.
+func CalcRequiredSurcharge(sp ds.SoilProfile, layerIndex int, drain ds.DrainData, drainage string, fillPressure, t, unitWeight float64) float64 {
+	target := calcLayerFinalSettlement(sp, layerIndex, fillPressure)
+	U := CalcDrainDegree(sp, layerIndex, drain, drainage, t)
+	settlementAt := func(surcharge float64) float64 {
+		return U * calcLayerFinalSettlement(sp, layerIndex, fillPressure+surcharge)
+	}
+
+	low, high := 0.0, MaxSurchargeRatio*fillPressure
+	if settlementAt(low) >= target {
+		return 0
+	}
+	if settlementAt(high) < target {
+		return math.NaN()
+	}
+	for i := 0; i < bisectionIterations; i++ {
+		mid := (low + high) / 2
+		if settlementAt(mid) >= target {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high / (unitWeight * sp.StressFactor())
+}
@@ -39,6 +39,12 @@ type SoilProfile struct {
 	Cc                  []float64 `json:"Cc"`
 	Gp                  []float64 `json:"Gp"`
 	Mv                  []float64 `json:"mv"`
+	Cv                  []float64 `json:"cv"`
+	Permeability        []float64 `json:"permeability"`
+	Calpha              []float64 `json:"C_alpha"`
+	CalphaRatio         []float64 `json:"C_alpha_ratio"` //Calpha/Cc, used when Calpha is not given
+	OCR                 []float64 `json:"OCR"`
+	Preconsolidation    []float64 `json:"preconsolidation_pressure"` //used instead of OCR when given
 	VS                  []float64 `json:"VS"`
 	VP                  []float64 `json:"VP"`
 	SPT                 []int     `json:"SPT"`
@@ -46,26 +52,101 @@ type SoilProfile struct {
 	PorePressure        []float64 `json:"pore_pressure"`   //for CPT
 	RQD                 []float64 `json:"RQD"`
 	IS50                []float64 `json:"IS50"`
+	GSI                 []float64 `json:"GSI"`            //for rock
+	Mi                  []float64 `json:"mi"`             //for rock
+	JointSpacing        []float64 `json:"joint_spacing"`  //for rock
+	JointAperture       []float64 `json:"joint_aperture"` //for rock
 	Kp                  []float64 `json:"Kp"`
 	DampingRatio        []float64 `json:"damping"`
+	PoreWaterType       []string  `json:"pore_water_type"`      //hydrostatic, piezometric, linear or dry
+	PiezometricLevel    []float64 `json:"piezometric_level"`    //depth of the piezometric surface, negative when artesian
+	PorePressureTop     []float64 `json:"pore_pressure_top"`    //for linear pore pressure
+	PorePressureBottom  []float64 `json:"pore_pressure_bottom"` //for linear pore pressure
 	Gwt                 float64   `json:"gwt"`
 	CheckGwt            bool      `json:"check_gwt"`
-	DensityUnit         string    `json:"density_unit"`
-	PressureUnit        string    `json:"pressure_unit"`
+	DensityUnit         string    `json:"density_unit"`  //kN/m3, t/m3 or pcf
+	PressureUnit        string    `json:"pressure_unit"` //kPa, t/m2, kg/cm2, psf or ksf
+}
+
+// Layer is a struct that contains the properties of a single layer of a soil profile
+type Layer struct {
+	SoilClass           string  `json:"soil_class"`
+	SoilType            string  `json:"soil_type"`
+	SoilDefinition      string  `json:"soil_definition"`
+	MaterialType        string  `json:"material_type"`
+	Thickness           float64 `json:"thickness"`
+	DryUnitWeight       float64 `json:"dry_unit_weight"`
+	SaturatedUnitWeight float64 `json:"saturated_unit_weight"`
+	FineContent         float64 `json:"fine_content"`
+	LiquidLimit         float64 `json:"liquid_limit"`
+	PlasticLimit        float64 `json:"plastic_limit"`
+	PlasticityIndex     float64 `json:"plasticity_index"`
+	Cu                  float64 `json:"Cu"`
+	Cohesion            float64 `json:"cohesion"`
+	Phi                 float64 `json:"phi"`
+	WaterContent        float64 `json:"water_content"`
+	PoissonRatio        float64 `json:"poisson_ratio"`
+	ElasticModulus      float64 `json:"elastic_modulus"`
+	ShearModulus        float64 `json:"shear_modulus"`
+	VoidRatio           float64 `json:"void_ratio"`
+	Cr                  float64 `json:"Cr"`
+	Cc                  float64 `json:"Cc"`
+	Gp                  float64 `json:"Gp"`
+	Mv                  float64 `json:"mv"`
+	Cv                  float64 `json:"cv"`
+	Permeability        float64 `json:"permeability"`
+	Calpha              float64 `json:"C_alpha"`
+	CalphaRatio         float64 `json:"C_alpha_ratio"` //Calpha/Cc, used when Calpha is not given
+	OCR                 float64 `json:"OCR"`
+	Preconsolidation    float64 `json:"preconsolidation_pressure"` //used instead of OCR when given
+	VS                  float64 `json:"VS"`
+	VP                  float64 `json:"VP"`
+	SPT                 int     `json:"SPT"`             //from the combined SPT log
+	ConeResistance      float64 `json:"cone_resistance"` //from the combined CPT log
+	PorePressure        float64 `json:"pore_pressure"`   //from the combined CPT log
+	RQD                 float64 `json:"RQD"`
+	IS50                float64 `json:"IS50"`
+	GSI                 float64 `json:"GSI"`            //for rock
+	Mi                  float64 `json:"mi"`             //for rock
+	JointSpacing        float64 `json:"joint_spacing"`  //for rock
+	JointAperture       float64 `json:"joint_aperture"` //for rock
+	Kp                  float64 `json:"Kp"`
+	DampingRatio        float64 `json:"damping"`
+	PoreWaterType       string  `json:"pore_water_type"`      //hydrostatic, piezometric, linear or dry
+	PiezometricLevel    float64 `json:"piezometric_level"`    //depth of the piezometric surface, negative when artesian
+	PorePressureTop     float64 `json:"pore_pressure_top"`    //for linear pore pressure
+	PorePressureBottom  float64 `json:"pore_pressure_bottom"` //for linear pore pressure
 }
 
 // BuildingData is a struct that contains the properties of a soil profile
 type BuildingData struct {
-	FoundationType      string  `json:"Foundation_Type"`
-	Df                  float64 `json:"Df"`
-	FoundationBaseAngle float64 `json:"Base_Angle"`
-	B                   float64 `json:"B"`
-	L                   float64 `json:"L"`
-	SlopeAngle          float64 `json:"Slope_Angle"`
-	Vx                  float64 `json:"HB"`
-	Vy                  float64 `json:"HL"`
-	FrictionCoefficient float64 `json:"FSS"`
-	Q                   float64 `json:"Q"`
+	FoundationType      string   `json:"Foundation_Type"`
+	Df                  float64  `json:"Df"`
+	FoundationBaseAngle float64  `json:"Base_Angle"`
+	B                   float64  `json:"B"`
+	L                   float64  `json:"L"`
+	SlopeAngle          float64  `json:"Slope_Angle"`
+	Vx                  float64  `json:"HB"`
+	Vy                  float64  `json:"HL"`
+	Mx                  float64  `json:"MB"` //moment in the direction of B at the top of the foundation
+	My                  float64  `json:"ML"` //moment in the direction of L at the top of the foundation
+	FrictionCoefficient float64  `json:"FSS"`
+	Q                   float64  `json:"Q"`
+	Pile                PileData `json:"Pile"`
+}
+
+// PileData is a struct that contains the properties of a pile
+type PileData struct {
+	Type     string  `json:"Pile_Type"` //driven or bored
+	Shape    string  `json:"Shape"`     //circular or square
+	Material string  `json:"Material"`  //concrete, steel or timber
+	Diameter float64 `json:"Diameter"`
+	Length   float64 `json:"Length"`
+	EI       float64 `json:"EI"`      //flexural rigidity of the pile section
+	EA       float64 `json:"EA"`      //axial rigidity of the pile section
+	Rows     int     `json:"Rows"`    //number of piles along L
+	Columns  int     `json:"Columns"` //number of piles along B
+	Spacing  float64 `json:"Spacing"` //center to center spacing of the piles
 }
 
 // SeismicData is a struct that contains the properties of earthquake data
@@ -104,3 +185,47 @@ type PressureMeterData struct {
 	Pressure    []float64 `json:"PL"`
 	NetPressure []float64 `json:"PL_net"`
 }
+
+// RetainingWallData is a struct that contains the geometry of a retaining wall. The back face of the stem is
+// vertical and the front face is battered when the stem is wider at the bottom.
+type RetainingWallData struct {
+	Type                string  `json:"Wall_Type"` //cantilever or gravity
+	Height              float64 `json:"Height"`    //from the bottom of the base to the top of the stem
+	StemTopWidth        float64 `json:"Stem_Top_Width"`
+	StemBottomWidth     float64 `json:"Stem_Bottom_Width"`
+	BaseWidth           float64 `json:"Base_Width"`
+	BaseThickness       float64 `json:"Base_Thickness"`
+	ToeWidth            float64 `json:"Toe_Width"`
+	Df                  float64 `json:"Df"`          //depth of the base below the ground in front of the wall
+	UnitWeight          float64 `json:"Unit_Weight"` //in the density unit of the backfill
+	BackfillSlope       float64 `json:"Backfill_Slope"`
+	WallFriction        float64 `json:"Wall_Friction"`
+	FrictionCoefficient float64 `json:"FSS"`
+}
+
+// Polyline is a struct that contains the coordinates of the points of a line in the cross-section of a slope
+type Polyline struct {
+	X []float64 `json:"X"`
+	Y []float64 `json:"Y"`
+}
+
+// SlopeData is a struct that contains the cross-section of a slope. The layer boundaries are the bottoms of the
+// layers of the soil profile from left to right, and the layers are horizontal below the highest point of the
+// ground surface when they are not given.
+type SlopeData struct {
+	Surface         Polyline   `json:"Surface"`
+	LayerBoundaries []Polyline `json:"Layer_Boundaries"`
+}
+
+// DrainData is a struct that contains the properties of prefabricated vertical drains
+type DrainData struct {
+	Pattern           string  `json:"Pattern"` //triangular or square
+	Spacing           float64 `json:"Spacing"`
+	Width             float64 `json:"Width"`
+	Thickness         float64 `json:"Thickness"`
+	SmearRatio        float64 `json:"Smear_Ratio"`        //diameter of the smear zone to the diameter of the drain
+	PermeabilityRatio float64 `json:"Permeability_Ratio"` //horizontal permeability of the undisturbed soil to that of the smear zone
+	Discharge         float64 `json:"qw"`                 //discharge capacity of the drain
+	Length            float64 `json:"Length"`             //length of the drain for drainage from one end
+	ChRatio           float64 `json:"Ch_Ratio"`           //horizontal to vertical coefficient of consolidation
+}
@@ -0,0 +1,87 @@
+package data_structures
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Sentinel errors of the error returning soil profile methods
+var (
+	ErrUnknownField    = errors.New("unknown field")
+	ErrTypeMismatch    = errors.New("type mismatch")
+	ErrEmptyProfile    = errors.New("empty soil profile")
+	ErrLayerIndex      = errors.New("layer index out of range")
+	ErrDepthOutOfRange = errors.New("depth out of range")
+	ErrUnknownUnit     = errors.New("unknown unit")
+)
+
+// TryGetFieldProperties returns the values of the given field for each layer in the soil profile, or
+// ErrUnknownField if the soil profile has no such field
+func (sp SoilProfile) TryGetFieldProperties(field string) (any, error) {
+	value := reflect.ValueOf(sp).FieldByName(field)
+	if !value.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
+	return value.Interface(), nil
+}
+
+// TrySetField sets the value of the given field for each layer in the soil profile, or returns ErrUnknownField if
+// the soil profile has no such field and ErrTypeMismatch if the value is not of the type of the field
+func (sp *SoilProfile) TrySetField(fieldName string, value interface{}) error {
+	fieldVal := reflect.ValueOf(sp).Elem().FieldByName(fieldName)
+	if !fieldVal.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUnknownField, fieldName)
+	}
+	newVal := reflect.ValueOf(value)
+	if !newVal.IsValid() || !newVal.Type().AssignableTo(fieldVal.Type()) {
+		return fmt.Errorf("%w: %s is %v, got %T", ErrTypeMismatch, fieldName, fieldVal.Type(), value)
+	}
+	fieldVal.Set(newVal)
+	return nil
+}
+
+// TryGetLayerIndex returns the index of the layer that contains the given depth, or ErrEmptyProfile if the soil
+// profile has no layers
+func (sp *SoilProfile) TryGetLayerIndex(depth float64) (int, error) {
+	if len(sp.Thickness) == 0 {
+		return 0, ErrEmptyProfile
+	}
+	return sp.GetLayerIndex(depth), nil
+}
+
+// TryCombineSPT combines the SPT log with the soil profile, or returns ErrEmptyProfile if the soil profile has no
+// layers and a ValidationError if the SPT log is not valid
+func (sp *SoilProfile) TryCombineSPT(sptLog SPTData) (SoilProfile, error) {
+	if len(sp.Thickness) == 0 {
+		return SoilProfile{}, ErrEmptyProfile
+	}
+	if err := sptLog.Validate(); err != nil {
+		return SoilProfile{}, err
+	}
+	return sp.CombineSPT(sptLog), nil
+}
+
+// TryCombineCPT combines the CPT log with the soil profile, or returns ErrEmptyProfile if the soil profile has no
+// layers and a ValidationError if the CPT log is not valid
+func (sp *SoilProfile) TryCombineCPT(cptLog CPTData) (SoilProfile, error) {
+	if len(sp.Thickness) == 0 {
+		return SoilProfile{}, ErrEmptyProfile
+	}
+	if err := cptLog.Validate(); err != nil {
+		return SoilProfile{}, err
+	}
+	return sp.CombineCPT(cptLog), nil
+}
+
+// TryCombineVS combines the VS log with the soil profile, or returns ErrEmptyProfile if the soil profile has no
+// layers and a ValidationError if the VS log is not valid
+func (sp *SoilProfile) TryCombineVS(vsLog MASWData) (SoilProfile, error) {
+	if len(sp.Thickness) == 0 {
+		return SoilProfile{}, ErrEmptyProfile
+	}
+	if err := vsLog.Validate(); err != nil {
+		return SoilProfile{}, err
+	}
+	return sp.CombineVS(vsLog), nil
+}
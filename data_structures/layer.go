@@ -0,0 +1,176 @@
+package data_structures
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// getLayerStructFields returns the names of the properties of a layer, which are the parallel slices of the soil
+// profile
+func getLayerStructFields() []string {
+	layerType := reflect.TypeOf(Layer{})
+	var fields []string
+	for i := 0; i < layerType.NumField(); i++ {
+		fields = append(fields, layerType.Field(i).Name)
+	}
+	return fields
+}
+
+// Layers returns the layers of the soil profile. The properties that are not given for a layer are zero.
+func (sp *SoilProfile) Layers() []Layer {
+	profileVal := reflect.ValueOf(sp).Elem()
+	layers := make([]Layer, len(sp.Thickness))
+	for i := range layers {
+		layerVal := reflect.ValueOf(&layers[i]).Elem()
+		for _, field := range getLayerStructFields() {
+			values := profileVal.FieldByName(field)
+			if values.Len() > i {
+				layerVal.FieldByName(field).Set(values.Index(i))
+			}
+		}
+	}
+	return layers
+}
+
+// ProfileFromLayers returns the soil profile of the layers. The properties that are zero in every layer are left
+// empty so that they are treated as not given.
+func ProfileFromLayers(layers []Layer) SoilProfile {
+	sp := SoilProfile{}
+	sp.setLayers(layers)
+	return sp
+}
+
+// setLayers replaces the parallel slices of the soil profile with the properties of the layers. The properties that
+// are given in the soil profile are kept even if they are zero in every layer, and the other properties are only set
+// when they are not zero in a layer.
+func (sp *SoilProfile) setLayers(layers []Layer) {
+	profileVal := reflect.ValueOf(sp).Elem()
+	for _, field := range getLayerStructFields() {
+		values := profileVal.FieldByName(field)
+		newValues := reflect.MakeSlice(values.Type(), 0, len(layers))
+		isGiven := !values.IsNil()
+		for _, layer := range layers {
+			value := reflect.ValueOf(layer).FieldByName(field)
+			isGiven = isGiven || !value.IsZero()
+			newValues = reflect.Append(newValues, value)
+		}
+		if isGiven {
+			values.Set(newValues)
+		} else {
+			values.Set(reflect.Zero(values.Type()))
+		}
+	}
+}
+
+// InsertLayer inserts the layer before the layer with the given index, or at the bottom of the soil profile when the
+// index is the number of layers
+func (sp *SoilProfile) InsertLayer(index int, layer Layer) error {
+	layers := sp.Layers()
+	if index < 0 || index > len(layers) {
+		return fmt.Errorf("%w: %d", ErrLayerIndex, index)
+	}
+	layers = append(layers[:index], append([]Layer{layer}, layers[index:]...)...)
+	sp.setLayers(layers)
+	return nil
+}
+
+// RemoveLayer removes the layer with the given index
+func (sp *SoilProfile) RemoveLayer(index int) error {
+	layers := sp.Layers()
+	if index < 0 || index >= len(layers) {
+		return fmt.Errorf("%w: %d", ErrLayerIndex, index)
+	}
+	sp.setLayers(append(layers[:index], layers[index+1:]...))
+	return nil
+}
+
+// SplitLayerAt splits the layer that contains the given depth into two layers with the same properties. The linear
+// pore pressures are interpolated at the depth, and nothing is changed when the depth is at a layer boundary.
+func (sp *SoilProfile) SplitLayerAt(depth float64) error {
+	if len(sp.Thickness) == 0 {
+		return ErrEmptyProfile
+	}
+	layerDepths := sp.GetLayerDepths()
+	if depth <= 0 || depth >= layerDepths[len(layerDepths)-1] {
+		return fmt.Errorf("%w: %v", ErrDepthOutOfRange, depth)
+	}
+	layerIndex := sp.GetLayerIndex(depth)
+	if depth == layerDepths[layerIndex] {
+		return nil
+	}
+
+	layers := sp.Layers()
+	upper, lower := layers[layerIndex], layers[layerIndex]
+	top := layerDepths[layerIndex] - upper.Thickness
+	ratio := (depth - top) / upper.Thickness
+	u := upper.PorePressureTop + (upper.PorePressureBottom-upper.PorePressureTop)*ratio
+	upper.Thickness, upper.PorePressureBottom = depth-top, u
+	lower.Thickness, lower.PorePressureTop = layerDepths[layerIndex]-depth, u
+
+	layers = append(layers[:layerIndex], append([]Layer{upper, lower}, layers[layerIndex+1:]...)...)
+	sp.setLayers(layers)
+	return nil
+}
+
+// MergeLayers merges the layers from the first to the last index into one layer. The numeric properties are
+// averaged by thickness, the text properties are taken from the thickest layer and the linear pore pressures are
+// taken from the top of the first and the bottom of the last layer. The pore water type and piezometric level are
+// taken from the last layer and the SPT blow count is the minimum of the layers.
+func (sp *SoilProfile) MergeLayers(first, last int) error {
+	layers := sp.Layers()
+	if first < 0 || first >= len(layers) {
+		return fmt.Errorf("%w: %d", ErrLayerIndex, first)
+	}
+	if last <= first || last >= len(layers) {
+		return fmt.Errorf("%w: %d", ErrLayerIndex, last)
+	}
+
+	merging := layers[first : last+1]
+	thickest := first
+	var thickness float64
+	for i, layer := range merging {
+		thickness += layer.Thickness
+		if layer.Thickness > layers[thickest].Thickness {
+			thickest = first + i
+		}
+	}
+
+	merged := layers[thickest]
+	mergedVal := reflect.ValueOf(&merged).Elem()
+	for _, field := range getLayerStructFields() {
+		value := mergedVal.FieldByName(field)
+		if value.Kind() == reflect.String {
+			continue
+		}
+		var sum float64
+		for _, layer := range merging {
+			layerValue := reflect.ValueOf(layer).FieldByName(field)
+			if layerValue.Kind() == reflect.Int {
+				sum += float64(layerValue.Int()) * layer.Thickness
+			} else {
+				sum += layerValue.Float() * layer.Thickness
+			}
+		}
+		if value.Kind() == reflect.Int {
+			value.SetInt(int64(math.Round(sum / thickness)))
+		} else {
+			value.SetFloat(sum / thickness)
+		}
+	}
+	merged.Thickness = thickness
+	merged.PorePressureTop = layers[first].PorePressureTop
+	merged.PorePressureBottom = layers[last].PorePressureBottom
+	merged.PoreWaterType = layers[last].PoreWaterType
+	merged.PiezometricLevel = layers[last].PiezometricLevel
+	merged.SPT = layers[first].SPT
+	for _, layer := range merging {
+		if layer.SPT < merged.SPT {
+			merged.SPT = layer.SPT
+		}
+	}
+
+	layers = append(layers[:first], append([]Layer{merged}, layers[last+1:]...)...)
+	sp.setLayers(layers)
+	return nil
+}
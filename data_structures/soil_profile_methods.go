@@ -2,8 +2,10 @@ package data_structures
 
 import (
 	np "github.com/geoport/numpy4go/vectors"
+	"math"
 	"reflect"
 	"sort"
+	"strings"
 )
 
 //GetLayerFields returns the fields of a soil layer
@@ -25,20 +27,10 @@ func (sp SoilProfile) GetFieldProperties(field string) any {
 	return reflect.ValueOf(sp).FieldByName(field).Interface()
 }
 
-//SetField sets the value of the given field for each layer in the soil profile
+//SetField sets the value of the given field for each layer in the soil profile. Unknown fields and values of a
+//different type are ignored, use TrySetField to get them as errors.
 func (sp *SoilProfile) SetField(fieldName string, value interface{}) {
-	v := reflect.ValueOf(sp).Elem()
-
-	fieldNames := map[string]int{}
-	for i := 0; i < v.NumField(); i++ {
-		typeField := v.Type().Field(i)
-		fieldNames[typeField.Name] = i
-	}
-	fieldNum := fieldNames[fieldName]
-
-	fieldVal := v.Field(fieldNum)
-	fieldVal.Set(reflect.ValueOf(value))
-
+	_ = sp.TrySetField(fieldName, value)
 }
 
 //GetLayerDepths returns the level of bottom of each layer in the soil profile
@@ -90,7 +82,7 @@ func (sp *SoilProfile) GetLayerIndex(depth float64) int {
 	return 0
 }
 
-// CalcNormalStress returns the normal stress at the given depth
+// CalcNormalStress returns the normal stress at the given depth in the pressure unit of the soil profile
 func (sp *SoilProfile) CalcNormalStress(depth float64) float64 {
 	Stresses := []float64{0}
 	gammaDry := sp.DryUnitWeight
@@ -123,22 +115,102 @@ func (sp *SoilProfile) CalcNormalStress(depth float64) float64 {
 		stress := (H-H0)*gammaDry[i] + gammaSaturated[i]*(H1-H)
 		Stresses = append(Stresses, stress+Stresses[i])
 	}
-	return Stresses[len(Stresses)-1]
+	return Stresses[len(Stresses)-1] * sp.StressFactor()
 }
 
-// calcEffectiveStress returns the effective stress at the given depth
-func (sp *SoilProfile) CalcEffectiveStress(depth float64) float64 {
-	normalStress := sp.CalcNormalStress(depth)
-	if sp.Gwt >= depth {
-		return normalStress
-	} else {
-		return normalStress - (depth-sp.Gwt)*0.981
+// CalcPorePressure returns the pore water pressure at the given depth from the pore water definition of the layer.
+// Hydrostatic layers use the ground water table, piezometric layers use their own piezometric level and linear
+// layers interpolate between the pore pressures at the top and bottom of the layer. Water is not considered unless
+// CheckGwt is set.
+func (sp *SoilProfile) CalcPorePressure(depth float64) float64 {
+	return sp.CalcLayerPorePressure(depth, sp.GetLayerIndex(depth))
+}
+
+// CalcLayerPorePressure returns the pore water pressure at the given depth from the pore water definition of the
+// layer with the given index, which is used where the layers are not horizontal. Linear layers take the pore
+// pressure at their top or bottom when the depth is outside the layer.
+func (sp *SoilProfile) CalcLayerPorePressure(depth float64, layerIndex int) float64 {
+	if !sp.CheckGwt {
+		return 0
+	}
+
+	switch sp.GetPoreWaterType(layerIndex) {
+	case "dry":
+		return 0
+	case "piezometric":
+		return math.Max(depth-sp.PiezometricLevel[layerIndex], 0) * sp.WaterUnitWeight()
+	case "linear":
+		layerDepths := sp.GetLayerDepths()
+		top := layerDepths[layerIndex] - sp.Thickness[layerIndex]
+		ratio := math.Min(math.Max((depth-top)/sp.Thickness[layerIndex], 0), 1)
+		uTop := sp.PorePressureTop[layerIndex]
+		return uTop + (sp.PorePressureBottom[layerIndex]-uTop)*ratio
+	default:
+		return math.Max(depth-sp.Gwt, 0) * sp.WaterUnitWeight()
+	}
+}
+
+// GetPoreWaterType returns the pore water type of the layer with the given index. Layers without a pore water type
+// and piezometric or linear layers without their pore pressure data are taken as hydrostatic.
+func (sp *SoilProfile) GetPoreWaterType(layerIndex int) string {
+	poreWaterType := "hydrostatic"
+	if len(sp.PoreWaterType) > layerIndex && sp.PoreWaterType[layerIndex] != "" {
+		poreWaterType = strings.ToLower(sp.PoreWaterType[layerIndex])
+	}
+	switch poreWaterType {
+	case "piezometric":
+		if len(sp.PiezometricLevel) <= layerIndex {
+			return "hydrostatic"
+		}
+	case "linear":
+		if len(sp.PorePressureTop) <= layerIndex || len(sp.PorePressureBottom) <= layerIndex {
+			return "hydrostatic"
+		}
+	}
+	return poreWaterType
+}
+
+// GetCohesion returns the effective cohesion of the layer with the given index, which is zero when the layer has no
+// cohesion
+func (sp *SoilProfile) GetCohesion(layerIndex int) float64 {
+	if len(sp.Cohesion) > layerIndex {
+		return sp.Cohesion[layerIndex]
+	}
+	return 0
+}
+
+// GetWaterDepths returns the depths within the layers at which the pore pressure starts to increase, which are the
+// ground water table for the hydrostatic layers and the piezometric levels for the piezometric layers. It is empty
+// unless CheckGwt is set.
+func (sp *SoilProfile) GetWaterDepths() []float64 {
+	if !sp.CheckGwt {
+		return nil
 	}
+	layerDepths := sp.GetLayerDepths()
+	var depths []float64
+	for i := range sp.Thickness {
+		level := sp.Gwt
+		switch sp.GetPoreWaterType(i) {
+		case "dry", "linear":
+			continue
+		case "piezometric":
+			level = sp.PiezometricLevel[i]
+		}
+		if level > layerDepths[i]-sp.Thickness[i] && level < layerDepths[i] && !np.Contains(depths, level) {
+			depths = append(depths, level)
+		}
+	}
+	return depths
+}
+
+// CalcEffectiveStress returns the effective stress at the given depth
+func (sp *SoilProfile) CalcEffectiveStress(depth float64) float64 {
+	return sp.CalcNormalStress(depth) - sp.CalcPorePressure(depth)
 }
 
 // IsCohesive returns true if the soil class of the layer at given depth is cohesive
 func (sp *SoilProfile) IsCohesive(depth float64) bool {
-	cohesiveSoils := []string{"SW-SC", "SP-SC", "SC", "SC-SM", "CL", "CL-ML", "CH,OH"}
+	cohesiveSoils := []string{"SW-SC", "SP-SC", "SC", "SC-SM", "CL", "CL-ML", "CH", "OH"}
 	layerIndex := sp.GetLayerIndex(depth)
 	soilClass := sp.SoilClass[layerIndex]
 	for _, cohesiveSoil := range cohesiveSoils {
@@ -149,6 +221,38 @@ func (sp *SoilProfile) IsCohesive(depth float64) bool {
 	return false
 }
 
+// IsRock returns true if the material type of the layer at given depth is rock
+func (sp *SoilProfile) IsRock(depth float64) bool {
+	layerIndex := sp.GetLayerIndex(depth)
+	if len(sp.MaterialType) <= layerIndex {
+		return false
+	}
+	return strings.EqualFold(sp.MaterialType[layerIndex], "rock")
+}
+
+// CalcPreconsolidationPressure returns the preconsolidation pressure at the given depth from the preconsolidation
+// pressure or OCR of the layer, the soil is normally consolidated when neither is given
+func (sp *SoilProfile) CalcPreconsolidationPressure(depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	sigma := sp.CalcEffectiveStress(depth)
+	if len(sp.Preconsolidation) > layerIndex && sp.Preconsolidation[layerIndex] > 0 {
+		return math.Max(sp.Preconsolidation[layerIndex], sigma)
+	}
+	if len(sp.OCR) > layerIndex && sp.OCR[layerIndex] > 0 {
+		return math.Max(sp.OCR[layerIndex], 1) * sigma
+	}
+	return sigma
+}
+
+// CalcOCR returns the overconsolidation ratio at the given depth
+func (sp *SoilProfile) CalcOCR(depth float64) float64 {
+	sigma := sp.CalcEffectiveStress(depth)
+	if sigma <= 0 {
+		return 1
+	}
+	return sp.CalcPreconsolidationPressure(depth) / sigma
+}
+
 // CombineSPT SPT log with soil profile
 func (sp *SoilProfile) CombineSPT(sptLog SPTData) SoilProfile {
 	sptDepth := sptLog.Depth
@@ -168,7 +272,7 @@ func (sp *SoilProfile) CombineSPT(sptLog SPTData) SoilProfile {
 		var newFieldValuesString []string
 		var newFieldValuesFloat []float64
 		var newN []int
-		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType"}, field)
+		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType", "PoreWaterType"}, field)
 		if isStringField {
 			oldFieldValuesString = sp.GetFieldProperties(field).([]string)
 		} else {
@@ -234,7 +338,7 @@ func (sp *SoilProfile) CombineCPT(cptLog CPTData) SoilProfile {
 		var newFieldValuesString []string
 		var newFieldValuesFloat []float64
 		var newConeResistance []float64
-		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType"}, field)
+		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType", "PoreWaterType"}, field)
 		if isStringField {
 			oldFieldValuesString = sp.GetFieldProperties(field).([]string)
 		} else {
@@ -301,7 +405,7 @@ func (sp *SoilProfile) CombineVS(vsLog MASWData) SoilProfile {
 		var newFieldValuesFloat []float64
 		var newVS []float64
 
-		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType"}, field)
+		isStringField := np.Contains([]string{"SoilClass", "SoilType", "SoilDefinition", "MaterialType", "PoreWaterType"}, field)
 
 		if isStringField {
 			oldFieldValuesString = sp.GetFieldProperties(field).([]string)
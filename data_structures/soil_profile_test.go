@@ -1,8 +1,10 @@
 package data_structures
 
 import (
+	"errors"
 	np "github.com/geoport/numpy4go/vectors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -12,6 +14,7 @@ var soilProfile = SoilProfile{
 	SaturatedUnitWeight: []float64{2, 2.1, 2.2},
 	Thickness:           []float64{1, 1.4, 3.4},
 	Gwt:                 1,
+	CheckGwt:            true,
 }
 
 var TestSPTData = SPTData{
@@ -128,11 +131,25 @@ func TestSoilProfile_GetLayerFields(t *testing.T) {
 		"Cc",
 		"Gp",
 		"Mv",
+		"Cv",
+		"Permeability",
+		"Calpha",
+		"CalphaRatio",
+		"OCR",
+		"Preconsolidation",
 		"VS",
 		"RQD",
 		"IS50",
+		"GSI",
+		"Mi",
+		"JointSpacing",
+		"JointAperture",
 		"Kp",
-		"DampingRatio"}
+		"DampingRatio",
+		"PoreWaterType",
+		"PiezometricLevel",
+		"PorePressureTop",
+		"PorePressureBottom"}
 
 	output := soilProfile.GetLayerFields()
 	if reflect.DeepEqual(output, expected) == false {
@@ -156,6 +173,14 @@ func TestSoilProfile_SetField(t *testing.T) {
 	if reflect.DeepEqual(output, expected) == false {
 		t.Errorf("Expected %v, got %v", expected, output)
 	}
+
+	// unknown fields and values of a different type are ignored
+	SP.SetField("Friction", []float64{30, 32, 34})
+	SP.SetField("FineContent", []string{"a", "b", "c"})
+	output = SP.FineContent
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
 }
 
 func TestSoilProfile_CombineSPT(t *testing.T) {
@@ -209,3 +234,475 @@ func TestSoilProfile_CombineVS(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expectedVS, outputVS)
 	}
 }
+
+func TestSoilProfile_IsCohesive(t *testing.T) {
+	SP := soilProfile
+	SP.SoilClass = []string{"CL", "CH", "OH"}
+	testInputs := []float64{0.5, 2, 4}
+	expectedOutputs := []bool{true, true, true}
+
+	for i, inp := range testInputs {
+		output := SP.IsCohesive(inp)
+		if output != expectedOutputs[i] {
+			t.Errorf("Expected %v, got %v", expectedOutputs[i], output)
+		}
+	}
+}
+
+func TestSoilProfile_IsRock(t *testing.T) {
+	SP := soilProfile
+	SP.MaterialType = []string{"Soil", "Soil", "Rock"}
+	testInputs := []float64{1, 2.4, 3}
+	expectedOutputs := []bool{false, false, true}
+
+	for i, inp := range testInputs {
+		output := SP.IsRock(inp)
+		if output != expectedOutputs[i] {
+			t.Errorf("Expected %v, got %v", expectedOutputs[i], output)
+		}
+	}
+}
+
+func TestSoilProfile_GetCohesion(t *testing.T) {
+	SP := soilProfile
+	SP.Cohesion = []float64{5, 10}
+	testInputs := []int{0, 1, 2}
+	expectedOutputs := []float64{5, 10, 0}
+
+	for i, inp := range testInputs {
+		output := SP.GetCohesion(inp)
+		if output != expectedOutputs[i] {
+			t.Errorf("Expected %v, got %v", expectedOutputs[i], output)
+		}
+	}
+}
+
+func TestSoilProfile_CalcOCR(t *testing.T) {
+	SP := soilProfile
+	SP.OCR = []float64{2, 0, 0}
+	SP.Preconsolidation = []float64{0, 10, 0}
+	testInputs := []float64{0.5, 2, 4}
+	expectedOCR := []float64{2, 3.4258, 1}
+	expectedPressure := []float64{1.8, 10, SP.CalcEffectiveStress(4)}
+
+	for i, inp := range testInputs {
+		OCR := np.RoundFloat(SP.CalcOCR(inp), 4)
+		pressure := np.RoundFloat(SP.CalcPreconsolidationPressure(inp), 4)
+		if OCR != expectedOCR[i] || pressure != np.RoundFloat(expectedPressure[i], 4) {
+			t.Errorf("Expected %v, got %v", []float64{expectedOCR[i], expectedPressure[i]}, []float64{OCR, pressure})
+		}
+	}
+}
+
+func TestSoilProfile_GetWaterDepths(t *testing.T) {
+	SP := soilProfile
+	SP.Gwt = 0.5
+	SP.PoreWaterType = []string{"", "piezometric", "dry"}
+	SP.PiezometricLevel = []float64{0, 1.8, 0}
+	expected := []float64{0.5, 1.8}
+	output := SP.GetWaterDepths()
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	var depths []float64
+	for _, row := range SP.GetStressProfile(1) {
+		depths = append(depths, row.Depth)
+	}
+	expected = []float64{0, 0.5, 1, 1.8, 2, 2.4, 3, 4, 5, 5.8}
+	if !reflect.DeepEqual(depths, expected) {
+		t.Errorf("Expected %v, got %v", expected, depths)
+	}
+}
+
+func TestSoilProfile_CalcPorePressure(t *testing.T) {
+	SP := soilProfile
+	SP.PoreWaterType = []string{"dry", "piezometric", "linear"}
+	SP.PiezometricLevel = []float64{0, -2, 0}
+	SP.PorePressureTop = []float64{0, 0, 2}
+	SP.PorePressureBottom = []float64{0, 0, 3}
+	testInputs := []float64{0.5, 2, 4}
+	expected := []float64{0, 3.924, 2.4706}
+	var output []float64
+	for _, inp := range testInputs {
+		output = append(output, SP.CalcPorePressure(inp))
+	}
+	output = np.Round(output, 4)
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	// piezometric and linear layers without their pore pressure data are hydrostatic
+	SP.PiezometricLevel = nil
+	SP.PorePressureBottom = nil
+	output = np.Round([]float64{SP.CalcPorePressure(0.5), SP.CalcPorePressure(2), SP.CalcPorePressure(4)}, 4)
+	expected = []float64{0, 0.981, 2.943}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	// the total stress does not depend on CheckGwt
+	SP.CheckGwt = false
+	output = np.Round([]float64{SP.CalcPorePressure(4), SP.CalcNormalStress(4)}, 4)
+	expected = []float64{0, 8.26}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSoilProfile_GetStressProfile(t *testing.T) {
+	profile := soilProfile.GetStressProfile(1)
+	expectedDepths := []float64{0, 1, 2, 2.4, 3, 4, 5, 5.8}
+	var outputDepths, outputPorePressure []float64
+	for _, row := range profile {
+		outputDepths = append(outputDepths, row.Depth)
+		outputPorePressure = append(outputPorePressure, row.PorePressure)
+	}
+	if reflect.DeepEqual(outputDepths, expectedDepths) == false {
+		t.Errorf("Expected %v, got %v", expectedDepths, outputDepths)
+	}
+	expectedPorePressure := []float64{0, 0, 0.981, 1.3734, 1.962, 2.943, 3.924, 4.7088}
+	if reflect.DeepEqual(np.Round(outputPorePressure, 4), expectedPorePressure) == false {
+		t.Errorf("Expected %v, got %v", expectedPorePressure, np.Round(outputPorePressure, 4))
+	}
+
+	// a non-positive interval falls back to the default interval
+	if !reflect.DeepEqual(soilProfile.GetStressProfile(0), soilProfile.GetStressProfile(DefaultStressInterval)) {
+		t.Errorf("Expected %v, got %v", soilProfile.GetStressProfile(DefaultStressInterval), soilProfile.GetStressProfile(0))
+	}
+
+	var csvOutput strings.Builder
+	if err := profile[:2].WriteCSV(&csvOutput); err != nil {
+		t.Fatal(err)
+	}
+	expectedCSV := "depth,layer_index,total_stress,pore_pressure,effective_stress\n0,0,0,0,0\n1,0,1.8,0,1.8\n"
+	if csvOutput.String() != expectedCSV {
+		t.Errorf("Expected %v, got %v", expectedCSV, csvOutput.String())
+	}
+
+	var jsonOutput strings.Builder
+	if err := profile[:1].WriteJSON(&jsonOutput); err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `[{"depth":0,"layer_index":0,"total_stress":0,"pore_pressure":0,"effective_stress":0}]` + "\n"
+	if jsonOutput.String() != expectedJSON {
+		t.Errorf("Expected %v, got %v", expectedJSON, jsonOutput.String())
+	}
+}
+
+func TestSoilProfile_ConvertUnits(t *testing.T) {
+	SP := SoilProfile{
+		SoilClass:           []string{"CL"},
+		DryUnitWeight:       []float64{18},
+		SaturatedUnitWeight: []float64{20},
+		Thickness:           []float64{2},
+		Cu:                  []float64{50},
+		Gwt:                 20,
+		DensityUnit:         "kN/m3",
+		PressureUnit:        "kPa",
+	}
+	testInputs := [][]string{{"kN/m3", "kPa"}, {"t/m3", "t/m2"}, {"kN/m3", "kg/cm2"}, {"pcf", "psf"}}
+	expected := [][]float64{
+		{2, 18, 50, 36, 9.8067},
+		{2, 1.8355, 5.0986, 3.6710, 1},
+		{2, 18, 0.5099, 0.3671, 0.1},
+		{6.5617, 114.5858, 1044.2717, 751.8756, 62.428},
+	}
+	for i, inp := range testInputs {
+		converted := SP.ConvertUnits(inp[0], inp[1])
+		output := np.Round([]float64{
+			converted.Thickness[0],
+			converted.DryUnitWeight[0],
+			converted.Cu[0],
+			converted.CalcNormalStress(converted.Thickness[0]),
+			converted.WaterUnitWeight(),
+		}, 4)
+		if !reflect.DeepEqual(output, expected[i]) {
+			t.Errorf("Expected %v, got %v", expected[i], output)
+		}
+	}
+}
+
+func TestSoilProfile_WaterUnitWeight(t *testing.T) {
+	SP := soilProfile
+	// the legacy value is used when neither unit is given
+	testInputs := [][]string{{"", ""}, {"kN/m3", "kPa"}, {"t/m3", "t/m2"}, {"pcf", "psf"}}
+	expected := []float64{0.981, 9.8067, 1, 62.428}
+	var output []float64
+	for _, inp := range testInputs {
+		SP.DensityUnit, SP.PressureUnit = inp[0], inp[1]
+		output = append(output, SP.WaterUnitWeight())
+	}
+	output = np.Round(output, 4)
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	SP.DensityUnit, SP.PressureUnit = "kN/m3", "kPa"
+	SP.DryUnitWeight = []float64{18, 17, 19}
+	SP.SaturatedUnitWeight = []float64{20, 21, 22}
+	output = np.Round([]float64{SP.CalcEffectiveStress(1.5)}, 4)
+	if !reflect.DeepEqual(output, []float64{23.5967}) {
+		t.Errorf("Expected %v, got %v", []float64{23.5967}, output)
+	}
+}
+
+func TestConvertPressure(t *testing.T) {
+	testInputs := []string{"kPa", "t/m2", "kg/cm2", "psf", "ksf"}
+	expected := []float64{100, 10.1972, 1.0197, 2088.5434, 2.0885}
+	var output []float64
+	for _, inp := range testInputs {
+		output = append(output, ConvertPressure(100, "kPa", inp))
+	}
+	output = np.Round(output, 4)
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestBuildingData_ConvertUnits(t *testing.T) {
+	bd := BuildingData{B: 2, Q: 100, Vx: 50, Pile: PileData{Length: 10, EA: 1000, EI: 1000}}
+	// forces are converted from kN to lbf and lengths from m to ft
+	expected := []float64{6.5617, 2088.5434, 11240.4469, 32.8084, 224808.9383, 2419823.2729}
+	converted := bd.ConvertUnits("kPa", "psf")
+	output := np.Round([]float64{
+		converted.B,
+		converted.Q,
+		converted.Vx,
+		converted.Pile.Length,
+		converted.Pile.EA,
+		converted.Pile.EI,
+	}, 4)
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestConvertUnits_UnknownUnit(t *testing.T) {
+	if _, err := TryConvertPressure(100, "kPa", "bar"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("Expected %v, got %v", ErrUnknownUnit, err)
+	}
+	if _, err := soilProfile.TryConvertUnits("kg/m3", "kPa"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("Expected %v, got %v", ErrUnknownUnit, err)
+	}
+	if _, err := (BuildingData{}).TryConvertUnits("kPa", "MPa"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("Expected %v, got %v", ErrUnknownUnit, err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("Expected %v, got %v", ErrUnknownUnit, err)
+		}
+	}()
+	SP := soilProfile
+	SP.PressureUnit = "bar"
+	SP.WaterUnitWeight()
+}
+
+func TestSoilProfile_Validate(t *testing.T) {
+	if err := soilProfile.Validate(); err != nil {
+		t.Errorf("Expected %v, got %v", nil, err)
+	}
+
+	SP := soilProfile
+	SP.DryUnitWeight = []float64{1.8, 2.2, 1.9}
+	SP.Phi = []float64{30, 55, 35}
+	SP.PoissonRatio = []float64{0.3, 0.3}
+	err := SP.Validate()
+	var validationError ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("Expected %T, got %v", validationError, err)
+	}
+	expected := []string{"PoissonRatio", "SaturatedUnitWeight", "Phi"}
+	var output []string
+	for _, issue := range validationError {
+		output = append(output, issue.Field)
+	}
+	if !reflect.DeepEqual(output, expected) || validationError[1].LayerIndex != 1 || validationError[2].LayerIndex != 1 {
+		t.Errorf("Expected %v, got %v", expected, validationError)
+	}
+}
+
+func TestSPTData_GetN60(t *testing.T) {
+	sptLog := SPTData{
+		Ce:         1.2,
+		Cb:         1,
+		Cs:         1,
+		Correction: true,
+		Depth:      []float64{2, 4, 6, 8, 10, 12},
+		N:          []int{8, 10, 20, 25, 30, 35},
+	}
+	expected := []float64{22.8, 42, 22.8}
+	feetLog := sptLog
+	feetLog.Depth = np.Round([]float64{6.56, 13.12, 19.69, 26.25, 32.81, 39.37}, 2)
+	output := np.Round([]float64{
+		sptLog.GetN60(5, "m"),
+		sptLog.GetN60(13, "m"),
+		feetLog.GetN60(16.4, "ft"),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcRodLengthCorrection(t *testing.T) {
+	expected := []float64{0.75, 0.85, 0.95, 1, 0.75, 0.85, 0.95, 1}
+	output := []float64{
+		CalcRodLengthCorrection(3, "m"),
+		CalcRodLengthCorrection(5, "m"),
+		CalcRodLengthCorrection(8, "m"),
+		CalcRodLengthCorrection(12, "m"),
+		CalcRodLengthCorrection(10, "ft"),
+		CalcRodLengthCorrection(16.5, "ft"),
+		CalcRodLengthCorrection(26, "ft"),
+		CalcRodLengthCorrection(40, "ft"),
+	}
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSPTData_Validate(t *testing.T) {
+	if err := TestSPTData.Validate(); err != nil {
+		t.Errorf("Expected %v, got %v", nil, err)
+	}
+
+	sptLog := SPTData{Depth: []float64{1.5, 3, 3}, N: []int{5, -1}}
+	expected := "Depth[2]: 3 is not greater than the previous depth 3; N: has 2 values, expected 3; N[1]: -1 must not be negative"
+	if err := sptLog.Validate(); err == nil || err.Error() != expected {
+		t.Errorf("Expected %v, got %v", expected, err)
+	}
+}
+
+func TestSoilProfile_TrySetField(t *testing.T) {
+	SP := soilProfile
+	testInputs := []struct {
+		field string
+		value any
+	}{
+		{"Phi", []float64{30, 32, 34}},
+		{"Friction", []float64{30, 32, 34}},
+		{"Phi", []int{30, 32, 34}},
+	}
+	expected := []error{nil, ErrUnknownField, ErrTypeMismatch}
+	for i, inp := range testInputs {
+		err := SP.TrySetField(inp.field, inp.value)
+		if !errors.Is(err, expected[i]) {
+			t.Errorf("Expected %v, got %v", expected[i], err)
+		}
+	}
+	if !reflect.DeepEqual(SP.SoilClass, soilProfile.SoilClass) || !reflect.DeepEqual(SP.Phi, []float64{30, 32, 34}) {
+		t.Errorf("Expected %v, got %v", []float64{30, 32, 34}, SP.Phi)
+	}
+
+	if _, err := SP.TryGetFieldProperties("Friction"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected %v, got %v", ErrUnknownField, err)
+	}
+	emptyProfile := SoilProfile{}
+	if _, err := emptyProfile.TryGetLayerIndex(1); !errors.Is(err, ErrEmptyProfile) {
+		t.Errorf("Expected %v, got %v", ErrEmptyProfile, err)
+	}
+	var validationError ValidationError
+	if _, err := SP.TryCombineSPT(SPTData{}); !errors.As(err, &validationError) {
+		t.Errorf("Expected %T, got %v", validationError, err)
+	}
+}
+
+func TestSoilProfile_Layers(t *testing.T) {
+	SP := soilProfile
+	SP.Phi = []float64{30, 32, 34}
+	output := ProfileFromLayers(SP.Layers())
+	output.Gwt, output.CheckGwt = SP.Gwt, SP.CheckGwt
+	output.DensityUnit, output.PressureUnit = SP.DensityUnit, SP.PressureUnit
+	if !reflect.DeepEqual(output, SP) {
+		t.Errorf("Expected %v, got %v", SP, output)
+	}
+}
+
+func TestSoilProfile_LayerOperations_ZeroColumns(t *testing.T) {
+	SP := soilProfile
+	SP.SoilClass = []string{"CL", "CH", "CL"}
+	SP.Phi = []float64{0, 0, 0}
+	SP.PoreWaterType = []string{"piezometric", "piezometric", "piezometric"}
+	SP.PiezometricLevel = []float64{0, 0, 0}
+
+	// the columns that are zero in every layer are kept by the layer operations
+	if err := SP.SplitLayerAt(4.1); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	output := [][]float64{SP.Phi, SP.PiezometricLevel}
+	expected := [][]float64{{0, 0, 0, 0}, {0, 0, 0, 0}}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+	if u := np.Round([]float64{SP.CalcPorePressure(5)}, 4); !reflect.DeepEqual(u, []float64{4.905}) {
+		t.Errorf("Expected %v, got %v", []float64{4.905}, u)
+	}
+}
+
+func TestSoilProfile_MergeLayers_LevelsAndSPT(t *testing.T) {
+	SP := soilProfile
+	SP.SPT = []int{12, 8, 20}
+	SP.PoreWaterType = []string{"", "piezometric", "piezometric"}
+	SP.PiezometricLevel = []float64{0, 1.5, 3}
+
+	// the piezometric level is taken from the lower layer and the SPT blow count is the minimum
+	if err := SP.MergeLayers(0, 1); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	if !reflect.DeepEqual(SP.SPT, []int{8, 20}) {
+		t.Errorf("Expected %v, got %v", []int{8, 20}, SP.SPT)
+	}
+	output := []any{SP.PoreWaterType, SP.PiezometricLevel}
+	expected := []any{[]string{"piezometric", "piezometric"}, []float64{1.5, 3}}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSoilProfile_LayerOperations(t *testing.T) {
+	SP := ProfileFromLayers(soilProfile.Layers())
+	SP.PoreWaterType = []string{"", "", "linear"}
+	SP.PorePressureTop = []float64{0, 0, 2}
+	SP.PorePressureBottom = []float64{0, 0, 3.7}
+
+	if err := SP.SplitLayerAt(4.1); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	output := [][]float64{np.Round(SP.Thickness, 4), SP.PorePressureTop, SP.PorePressureBottom}
+	expected := [][]float64{{1, 1.4, 1.7, 1.7}, {0, 0, 2, 2.85}, {0, 0, 2.85, 3.7}}
+	if !reflect.DeepEqual(output, expected) || len(SP.SoilClass) != 4 {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	if err := SP.MergeLayers(0, 1); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	output = [][]float64{np.Round(SP.Thickness, 4), np.Round(SP.DryUnitWeight, 4)}
+	expected = [][]float64{{2.4, 1.7, 1.7}, {1.7417, 1.9, 1.9}}
+	if !reflect.DeepEqual(output, expected) || SP.SoilClass[0] != "SP" {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	if err := SP.InsertLayer(0, Layer{SoilClass: "CL", Thickness: 0.5, DryUnitWeight: 1.6}); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	if err := SP.RemoveLayer(3); err != nil {
+		t.Fatalf("Expected %v, got %v", nil, err)
+	}
+	expectedClasses := []string{"CL", "SP", "SM"}
+	if !reflect.DeepEqual(SP.SoilClass, expectedClasses) {
+		t.Errorf("Expected %v, got %v", expectedClasses, SP.SoilClass)
+	}
+	saturatedUnitWeights := np.Round(SP.SaturatedUnitWeight, 4)
+	if !reflect.DeepEqual(saturatedUnitWeights, []float64{0, 2.0583, 2.2}) {
+		t.Errorf("Expected %v, got %v", []float64{0, 2.0583, 2.2}, saturatedUnitWeights)
+	}
+
+	if err := SP.RemoveLayer(3); !errors.Is(err, ErrLayerIndex) {
+		t.Errorf("Expected %v, got %v", ErrLayerIndex, err)
+	}
+	if err := SP.SplitLayerAt(10); !errors.Is(err, ErrDepthOutOfRange) {
+		t.Errorf("Expected %v, got %v", ErrDepthOutOfRange, err)
+	}
+}
@@ -0,0 +1,34 @@
+package data_structures
+
+// CalcRodLengthCorrection returns the SPT rod length correction factor for the given depth in the given length unit
+// (Skempton, 1986)
+func CalcRodLengthCorrection(depth float64, lengthUnit string) float64 {
+	depth = ConvertLength(depth, lengthUnit, "m")
+	switch {
+	case depth < 4:
+		return 0.75
+	case depth < 6:
+		return 0.85
+	case depth < 10:
+		return 0.95
+	default:
+		return 1
+	}
+}
+
+// GetN60 returns the SPT blow count of the test interval containing the given depth, corrected to 60% energy
+// efficiency when the SPT log requires correction. The depths of the SPT log are in the given length unit.
+func (sptLog *SPTData) GetN60(depth float64, lengthUnit string) float64 {
+	index := len(sptLog.Depth) - 1
+	for i, testDepth := range sptLog.Depth {
+		if depth <= testDepth {
+			index = i
+			break
+		}
+	}
+	N := float64(sptLog.N[index])
+	if sptLog.Correction {
+		N *= sptLog.Ce * sptLog.Cb * sptLog.Cs * CalcRodLengthCorrection(sptLog.Depth[index], lengthUnit)
+	}
+	return N
+}
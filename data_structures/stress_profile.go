@@ -0,0 +1,79 @@
+package data_structures
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// DefaultStressInterval is the sampling interval of the stress profile used when the given interval is not positive
+const DefaultStressInterval = 0.5
+
+// StressRow is a struct that contains the vertical stresses at a depth of the soil profile
+type StressRow struct {
+	Depth           float64 `json:"depth"`
+	LayerIndex      int     `json:"layer_index"`
+	TotalStress     float64 `json:"total_stress"`
+	PorePressure    float64 `json:"pore_pressure"`
+	EffectiveStress float64 `json:"effective_stress"`
+}
+
+// StressProfile is a list of vertical stresses ordered by depth
+type StressProfile []StressRow
+
+// GetStressProfile returns the total stress, pore pressure and effective stress from the ground surface to the
+// bottom of the soil profile, sampled at the given interval, at every layer boundary and where the pore pressure
+// starts to increase. DefaultStressInterval is used when the interval is not positive.
+func (sp *SoilProfile) GetStressProfile(interval float64) StressProfile {
+	if !(interval > 0) {
+		interval = DefaultStressInterval
+	}
+	layerDepths := sp.GetLayerDepths()
+	bottom := layerDepths[len(layerDepths)-1]
+
+	depths := append(np.Arange(0, bottom, interval), layerDepths...)
+	depths = append(depths, sp.GetWaterDepths()...)
+	depths = np.Unique(np.Round(depths, 6))
+	sort.Float64s(depths)
+
+	var profile StressProfile
+	for _, depth := range depths {
+		profile = append(profile, StressRow{
+			Depth:           depth,
+			LayerIndex:      sp.GetLayerIndex(depth),
+			TotalStress:     sp.CalcNormalStress(depth),
+			PorePressure:    sp.CalcPorePressure(depth),
+			EffectiveStress: sp.CalcEffectiveStress(depth),
+		})
+	}
+	return profile
+}
+
+// WriteCSV writes the stress profile to w as comma separated values with a header row
+func (profile StressProfile) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	records := [][]string{{"depth", "layer_index", "total_stress", "pore_pressure", "effective_stress"}}
+	for _, row := range profile {
+		records = append(records, []string{
+			format(row.Depth),
+			strconv.Itoa(row.LayerIndex),
+			format(row.TotalStress),
+			format(row.PorePressure),
+			format(row.EffectiveStress),
+		})
+	}
+	return writer.WriteAll(records)
+}
+
+// WriteJSON writes the stress profile to w as a JSON array of rows
+func (profile StressProfile) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(profile)
+}
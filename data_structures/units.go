@@ -0,0 +1,251 @@
+package data_structures
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StandardGravity is the gravitational acceleration in m/s² used to convert masses to weights
+const StandardGravity = 9.80665
+
+// AtmosphericPressure is the atmospheric pressure in kPa
+const AtmosphericPressure = 100.0
+
+// legacyWaterUnitWeight is the unit weight of water used when the units of the soil profile are not given, which
+// keeps the results of the profiles written in t/m³ and t/m² before the units were introduced
+const legacyWaterUnitWeight = 0.981
+
+// pressureFactors are the values of the pressure units in kPa. An empty unit is taken as kPa.
+var pressureFactors = map[string]float64{
+	"":       1,
+	"kpa":    1,
+	"t/m2":   StandardGravity,
+	"kg/cm2": 10 * StandardGravity,
+	"psf":    0.04788026,
+	"ksf":    47.88026,
+}
+
+// unitWeightFactors are the values of the unit weight units in kN/m³. An empty unit is taken as kN/m³.
+var unitWeightFactors = map[string]float64{
+	"":      1,
+	"kn/m3": 1,
+	"t/m3":  StandardGravity,
+	"pcf":   0.1570875,
+}
+
+// lengthFactors are the values of the length units in m
+var lengthFactors = map[string]float64{
+	"m":  1,
+	"ft": 0.3048,
+}
+
+// getUnitFactor returns the factor of the unit from the given factors, or ErrUnknownUnit if the unit is unknown
+func getUnitFactor(factors map[string]float64, unit string) (float64, error) {
+	key := strings.NewReplacer("³", "3", "²", "2", " ", "").Replace(strings.ToLower(unit))
+	factor, ok := factors[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+	}
+	return factor, nil
+}
+
+// convert converts the value from one unit to another with the given factors, or returns ErrUnknownUnit if either
+// unit is unknown
+func convert(factors map[string]float64, value float64, from, to string) (float64, error) {
+	fromFactor, err := getUnitFactor(factors, from)
+	if err != nil {
+		return 0, err
+	}
+	toFactor, err := getUnitFactor(factors, to)
+	if err != nil {
+		return 0, err
+	}
+	return value * fromFactor / toFactor, nil
+}
+
+// mustConvert converts the value from one unit to another with the given factors and panics if either unit is
+// unknown
+func mustConvert(factors map[string]float64, value float64, from, to string) float64 {
+	converted, err := convert(factors, value, from, to)
+	if err != nil {
+		panic(err)
+	}
+	return converted
+}
+
+// TryConvertPressure converts the pressure from one pressure unit to another, or returns ErrUnknownUnit if either
+// unit is unknown
+func TryConvertPressure(value float64, from, to string) (float64, error) {
+	return convert(pressureFactors, value, from, to)
+}
+
+// TryConvertUnitWeight converts the unit weight from one unit weight unit to another, or returns ErrUnknownUnit if
+// either unit is unknown
+func TryConvertUnitWeight(value float64, from, to string) (float64, error) {
+	return convert(unitWeightFactors, value, from, to)
+}
+
+// TryConvertLength converts the length from one length unit to another, or returns ErrUnknownUnit if either unit is
+// unknown
+func TryConvertLength(value float64, from, to string) (float64, error) {
+	return convert(lengthFactors, value, from, to)
+}
+
+// ConvertPressure converts the pressure from one pressure unit to another. It panics if either unit is unknown,
+// TryConvertPressure returns it as an error.
+func ConvertPressure(value float64, from, to string) float64 {
+	return mustConvert(pressureFactors, value, from, to)
+}
+
+// ConvertUnitWeight converts the unit weight from one unit weight unit to another. It panics if either unit is
+// unknown, TryConvertUnitWeight returns it as an error.
+func ConvertUnitWeight(value float64, from, to string) float64 {
+	return mustConvert(unitWeightFactors, value, from, to)
+}
+
+// ConvertLength converts the length from one length unit to another. It panics if either unit is unknown,
+// TryConvertLength returns it as an error.
+func ConvertLength(value float64, from, to string) float64 {
+	return mustConvert(lengthFactors, value, from, to)
+}
+
+// GetLengthUnit returns the length unit of the pressure unit, which is ft for imperial units and m otherwise
+func GetLengthUnit(pressureUnit string) string {
+	switch strings.ToLower(pressureUnit) {
+	case "psf", "ksf":
+		return "ft"
+	default:
+		return "m"
+	}
+}
+
+// scaleValues returns a copy of the values multiplied by the factor
+func scaleValues(values []float64, factor float64) []float64 {
+	if values == nil {
+		return nil
+	}
+	scaled := make([]float64, len(values))
+	for i, value := range values {
+		scaled[i] = value * factor
+	}
+	return scaled
+}
+
+// LengthUnit returns the length unit of the soil profile
+func (sp *SoilProfile) LengthUnit() string {
+	return GetLengthUnit(sp.PressureUnit)
+}
+
+// StressFactor returns the factor that converts the unit weight times the length to the pressure unit of the soil
+// profile. Like the other unit methods of the soil profile, it panics if the units are unknown, which Validate
+// reports beforehand.
+func (sp *SoilProfile) StressFactor() float64 {
+	return ConvertPressure(ConvertUnitWeight(ConvertLength(1, sp.LengthUnit(), "m"), sp.DensityUnit, "kN/m3"), "kPa", sp.PressureUnit)
+}
+
+// WaterUnitWeight returns the unit weight of water as the increase of the hydrostatic pressure per unit length in
+// the units of the soil profile. The legacy value of 0.981 is used when neither unit is given.
+func (sp *SoilProfile) WaterUnitWeight() float64 {
+	if sp.DensityUnit == "" && sp.PressureUnit == "" {
+		return legacyWaterUnitWeight
+	}
+	return ConvertPressure(StandardGravity*ConvertLength(1, sp.LengthUnit(), "m"), "kPa", sp.PressureUnit)
+}
+
+// AtmosphericPressure returns the atmospheric pressure in the pressure unit of the soil profile
+func (sp *SoilProfile) AtmosphericPressure() float64 {
+	return ConvertPressure(AtmosphericPressure, "kPa", sp.PressureUnit)
+}
+
+// TryConvertUnits returns a copy of the soil profile with the layer fields converted to the given unit weight and
+// pressure units, or ErrUnknownUnit if any of the units is unknown. The length unit follows the pressure unit, the
+// coefficient of volume compressibility is converted as an inverse pressure and the coefficient of consolidation and
+// permeability as length based rates.
+func (sp SoilProfile) TryConvertUnits(densityUnit, pressureUnit string) (SoilProfile, error) {
+	unitWeight, err := TryConvertUnitWeight(1, sp.DensityUnit, densityUnit)
+	if err != nil {
+		return SoilProfile{}, err
+	}
+	pressure, err := TryConvertPressure(1, sp.PressureUnit, pressureUnit)
+	if err != nil {
+		return SoilProfile{}, err
+	}
+	length := ConvertLength(1, sp.LengthUnit(), GetLengthUnit(pressureUnit))
+
+	converted := sp
+	converted.DryUnitWeight = scaleValues(sp.DryUnitWeight, unitWeight)
+	converted.SaturatedUnitWeight = scaleValues(sp.SaturatedUnitWeight, unitWeight)
+
+	converted.Cu = scaleValues(sp.Cu, pressure)
+	converted.Cohesion = scaleValues(sp.Cohesion, pressure)
+	converted.ElasticModulus = scaleValues(sp.ElasticModulus, pressure)
+	converted.ShearModulus = scaleValues(sp.ShearModulus, pressure)
+	converted.Preconsolidation = scaleValues(sp.Preconsolidation, pressure)
+	converted.IS50 = scaleValues(sp.IS50, pressure)
+	converted.ConeResistance = scaleValues(sp.ConeResistance, pressure)
+	converted.PorePressure = scaleValues(sp.PorePressure, pressure)
+	converted.PorePressureTop = scaleValues(sp.PorePressureTop, pressure)
+	converted.PorePressureBottom = scaleValues(sp.PorePressureBottom, pressure)
+	converted.Mv = scaleValues(sp.Mv, 1/pressure)
+
+	converted.Thickness = scaleValues(sp.Thickness, length)
+	converted.PiezometricLevel = scaleValues(sp.PiezometricLevel, length)
+	converted.JointSpacing = scaleValues(sp.JointSpacing, length)
+	converted.JointAperture = scaleValues(sp.JointAperture, length)
+	converted.Permeability = scaleValues(sp.Permeability, length)
+	converted.Cv = scaleValues(sp.Cv, length*length)
+	converted.Gwt = sp.Gwt * length
+
+	converted.DensityUnit = densityUnit
+	converted.PressureUnit = pressureUnit
+	return converted, nil
+}
+
+// ConvertUnits returns a copy of the soil profile with the layer fields converted to the given unit weight and
+// pressure units. It panics if any of the units is unknown, TryConvertUnits returns it as an error.
+func (sp SoilProfile) ConvertUnits(densityUnit, pressureUnit string) SoilProfile {
+	converted, err := sp.TryConvertUnits(densityUnit, pressureUnit)
+	if err != nil {
+		panic(err)
+	}
+	return converted
+}
+
+// TryConvertUnits returns a copy of the building data converted from one pressure unit to another, or
+// ErrUnknownUnit if either unit is unknown. Lengths follow the pressure units, forces are converted as the pressure
+// times the square of the length and moments as the force times the length.
+func (bd BuildingData) TryConvertUnits(fromPressureUnit, toPressureUnit string) (BuildingData, error) {
+	pressure, err := TryConvertPressure(1, fromPressureUnit, toPressureUnit)
+	if err != nil {
+		return BuildingData{}, err
+	}
+	length := ConvertLength(1, GetLengthUnit(fromPressureUnit), GetLengthUnit(toPressureUnit))
+	force := pressure * length * length
+
+	converted := bd
+	converted.Df = bd.Df * length
+	converted.B = bd.B * length
+	converted.L = bd.L * length
+	converted.Vx = bd.Vx * force
+	converted.Vy = bd.Vy * force
+	converted.Mx = bd.Mx * force * length
+	converted.My = bd.My * force * length
+	converted.Q = bd.Q * pressure
+
+	converted.Pile.Diameter = bd.Pile.Diameter * length
+	converted.Pile.Length = bd.Pile.Length * length
+	converted.Pile.Spacing = bd.Pile.Spacing * length
+	converted.Pile.EA = bd.Pile.EA * force
+	converted.Pile.EI = bd.Pile.EI * force * length * length
+	return converted, nil
+}
+
+// ConvertUnits returns a copy of the building data converted from one pressure unit to another. It panics if either
+// unit is unknown, TryConvertUnits returns it as an error.
+func (bd BuildingData) ConvertUnits(fromPressureUnit, toPressureUnit string) BuildingData {
+	converted, err := bd.TryConvertUnits(fromPressureUnit, toPressureUnit)
+	if err != nil {
+		panic(err)
+	}
+	return converted
+}
@@ -0,0 +1,238 @@
+package data_structures
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// ValidationIssue is a struct that contains a problem found in a field. The index is the layer index for the soil
+// profile and MASW data, the row index for the field logs and -1 when the issue concerns the whole field.
+type ValidationIssue struct {
+	Field      string `json:"field"`
+	LayerIndex int    `json:"layer_index"`
+	Message    string `json:"message"`
+}
+
+// Error returns the issue as text
+func (issue ValidationIssue) Error() string {
+	if issue.LayerIndex < 0 {
+		return fmt.Sprintf("%s: %s", issue.Field, issue.Message)
+	}
+	return fmt.Sprintf("%s[%d]: %s", issue.Field, issue.LayerIndex, issue.Message)
+}
+
+// ValidationError is the list of every issue found by a validation
+type ValidationError []ValidationIssue
+
+// Error returns the issues as text separated by semicolons
+func (err ValidationError) Error() string {
+	var messages []string
+	for _, issue := range err {
+		messages = append(messages, issue.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validator collects the issues of a validation
+type validator struct {
+	issues ValidationError
+}
+
+// add records an issue of the field at the given index
+func (v *validator) add(field string, index int, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{Field: field, LayerIndex: index, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkLength records an issue when the length of the field is not n. Optional fields may be empty.
+func (v *validator) checkLength(field string, length, n int, optional bool) {
+	if length == n || (optional && length == 0) {
+		return
+	}
+	v.add(field, -1, "has %d values, expected %d", length, n)
+}
+
+// checkValue records an issue when the value of the field at the given index is outside the given range
+func (v *validator) checkValue(field string, index int, value, min, max float64) {
+	if math.IsNaN(value) || value < min || value > max {
+		v.add(field, index, "%v is out of range [%v, %v]", value, min, max)
+	}
+}
+
+// checkRange records an issue for each value of the field outside the given range
+func (v *validator) checkRange(field string, values []float64, min, max float64) {
+	for i, value := range values {
+		v.checkValue(field, i, value, min, max)
+	}
+}
+
+// checkPositiveValue records an issue when the value of the field at the given index is not positive
+func (v *validator) checkPositiveValue(field string, index int, value float64) {
+	if !(value > 0) {
+		v.add(field, index, "%v must be positive", value)
+	}
+}
+
+// checkPositive records an issue for each value of the field that is not positive
+func (v *validator) checkPositive(field string, values []float64) {
+	for i, value := range values {
+		v.checkPositiveValue(field, i, value)
+	}
+}
+
+// checkDepths records an issue when the depths of a log are empty, negative or not increasing
+func (v *validator) checkDepths(field string, depths []float64) {
+	if len(depths) == 0 {
+		v.add(field, -1, "must not be empty")
+		return
+	}
+	for i, depth := range depths {
+		if depth < 0 {
+			v.add(field, i, "%v must not be negative", depth)
+		}
+		if i > 0 && depth <= depths[i-1] {
+			v.add(field, i, "%v is not greater than the previous depth %v", depth, depths[i-1])
+		}
+	}
+}
+
+// err returns the collected issues, or nil if there are none
+func (v *validator) err() error {
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return v.issues
+}
+
+// Validate checks the soil profile and returns a ValidationError listing every issue, or nil if it is valid
+func (sp *SoilProfile) Validate() error {
+	v := validator{}
+	n := len(sp.Thickness)
+	if n == 0 {
+		v.add("Thickness", -1, "must not be empty")
+		return v.err()
+	}
+	v.checkPositive("Thickness", sp.Thickness)
+
+	requiredFields := []string{"SoilClass", "DryUnitWeight", "SaturatedUnitWeight"}
+	val := reflect.ValueOf(sp).Elem()
+	for _, field := range sp.GetLayerFields() {
+		value := val.FieldByName(field)
+		if value.Kind() == reflect.Slice {
+			v.checkLength(field, value.Len(), n, !np.Contains(requiredFields, field))
+		}
+	}
+
+	v.checkPositive("DryUnitWeight", sp.DryUnitWeight)
+	v.checkPositive("SaturatedUnitWeight", sp.SaturatedUnitWeight)
+	for i := range sp.SaturatedUnitWeight {
+		if i < len(sp.DryUnitWeight) && sp.SaturatedUnitWeight[i] < sp.DryUnitWeight[i] {
+			v.add("SaturatedUnitWeight", i, "%v is less than the dry unit weight %v", sp.SaturatedUnitWeight[i], sp.DryUnitWeight[i])
+		}
+	}
+	v.checkRange("Phi", sp.Phi, 0, 50)
+	v.checkRange("PoissonRatio", sp.PoissonRatio, 0, 0.5)
+	v.checkRange("Cu", sp.Cu, 0, math.Inf(1))
+	v.checkRange("Cohesion", sp.Cohesion, 0, math.Inf(1))
+
+	for i, poreWaterType := range sp.PoreWaterType {
+		switch strings.ToLower(poreWaterType) {
+		case "", "hydrostatic", "dry":
+		case "piezometric":
+			if len(sp.PiezometricLevel) <= i {
+				v.add("PiezometricLevel", i, "is required for piezometric pore pressure")
+			}
+		case "linear":
+			if len(sp.PorePressureTop) <= i || len(sp.PorePressureBottom) <= i {
+				v.add("PorePressureTop", i, "top and bottom pore pressures are required for linear pore pressure")
+			}
+		default:
+			v.add("PoreWaterType", i, "unknown pore water type %q", poreWaterType)
+		}
+	}
+
+	if _, err := getUnitFactor(unitWeightFactors, sp.DensityUnit); err != nil {
+		v.add("DensityUnit", -1, "unknown unit %q", sp.DensityUnit)
+	}
+	if _, err := getUnitFactor(pressureFactors, sp.PressureUnit); err != nil {
+		v.add("PressureUnit", -1, "unknown unit %q", sp.PressureUnit)
+	}
+	return v.err()
+}
+
+// Validate checks the SPT log and returns a ValidationError listing every issue, or nil if it is valid
+func (sptLog *SPTData) Validate() error {
+	v := validator{}
+	v.checkDepths("Depth", sptLog.Depth)
+	v.checkLength("N", len(sptLog.N), len(sptLog.Depth), false)
+	for i, N := range sptLog.N {
+		if N < 0 {
+			v.add("N", i, "%d must not be negative", N)
+		}
+	}
+	if sptLog.Correction {
+		v.checkPositiveValue("Ce", -1, sptLog.Ce)
+		v.checkPositiveValue("Cb", -1, sptLog.Cb)
+		v.checkPositiveValue("Cs", -1, sptLog.Cs)
+	}
+	return v.err()
+}
+
+// Validate checks the CPT log and returns a ValidationError listing every issue, or nil if it is valid
+func (cptLog *CPTData) Validate() error {
+	v := validator{}
+	v.checkDepths("Depth", cptLog.Depth)
+	v.checkLength("ConeResistance", len(cptLog.ConeResistance), len(cptLog.Depth), false)
+	v.checkLength("PorePressure", len(cptLog.PorePressure), len(cptLog.Depth), true)
+	v.checkRange("ConeResistance", cptLog.ConeResistance, 0, math.Inf(1))
+	return v.err()
+}
+
+// Validate checks the MASW log and returns a ValidationError listing every issue, or nil if it is valid
+func (vsLog *MASWData) Validate() error {
+	v := validator{}
+	n := len(vsLog.Thickness)
+	if n == 0 {
+		v.add("Thickness", -1, "must not be empty")
+		return v.err()
+	}
+	v.checkPositive("Thickness", vsLog.Thickness)
+	v.checkLength("VS", len(vsLog.VS), n, false)
+	v.checkLength("VP", len(vsLog.VP), n, true)
+	v.checkPositive("VS", vsLog.VS)
+	for i := range vsLog.VP {
+		if i < len(vsLog.VS) && vsLog.VP[i] <= vsLog.VS[i] {
+			v.add("VP", i, "%v is not greater than VS %v", vsLog.VP[i], vsLog.VS[i])
+		}
+	}
+	return v.err()
+}
+
+// Validate checks the pressure meter log and returns a ValidationError listing every issue, or nil if it is valid
+func (pmData *PressureMeterData) Validate() error {
+	v := validator{}
+	v.checkDepths("Depth", pmData.Depth)
+	v.checkLength("Pressure", len(pmData.Pressure), len(pmData.Depth), false)
+	v.checkLength("NetPressure", len(pmData.NetPressure), len(pmData.Depth), true)
+	v.checkRange("Pressure", pmData.Pressure, 0, math.Inf(1))
+	v.checkRange("NetPressure", pmData.NetPressure, 0, math.Inf(1))
+	return v.err()
+}
+
+// Validate checks the building data and returns a ValidationError listing every issue, or nil if it is valid
+func (bd *BuildingData) Validate() error {
+	v := validator{}
+	v.checkPositiveValue("B", -1, bd.B)
+	v.checkPositiveValue("L", -1, bd.L)
+	v.checkValue("Df", -1, bd.Df, 0, math.Inf(1))
+	v.checkValue("Q", -1, bd.Q, 0, math.Inf(1))
+	v.checkValue("SlopeAngle", -1, bd.SlopeAngle, 0, 90)
+	v.checkValue("FrictionCoefficient", -1, bd.FrictionCoefficient, 0, math.Inf(1))
+	if bd.Pile.Length > 0 {
+		v.checkPositiveValue("Pile.Diameter", -1, bd.Pile.Diameter)
+	}
+	return v.err()
+}
@@ -0,0 +1,148 @@
+package earth_pressure
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var sandProfile = ds.SoilProfile{
+	SoilClass:           []string{"SP"},
+	Thickness:           []float64{10},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{20},
+	Phi:                 []float64{30},
+	Gwt:                 10,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var layeredProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL", "SP"},
+	Thickness:           []float64{3, 7},
+	DryUnitWeight:       []float64{18, 18},
+	SaturatedUnitWeight: []float64{19, 20},
+	Phi:                 []float64{0, 32},
+	Cohesion:            []float64{10, 0},
+	Gwt:                 4,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+func TestEarthPressureCoefficients(t *testing.T) {
+	expected := []float64{0.3333, 3, 0.3333, 3, 0.5, 0.2973, 0.34, 0.3729, 4.1433}
+	output := np.Round([]float64{
+		CalcRankineKa(30, 0),
+		CalcRankineKp(30, 0),
+		CalcCoulombKa(30, 0, 0),
+		CalcCoulombKp(30, 0, 0),
+		CalcK0(30),
+		CalcCoulombKa(30, 20, 0),
+		CalcCoulombKa(30, 20, 10),
+		CalcRankineKa(30, 15),
+		CalcCoulombKp(30, 10, 0),
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcActivePressure(t *testing.T) {
+	expected := []float64{75, 3.3333, 0}
+	diagram := CalcActivePressure(sandProfile, 5, 0.5, "rankine", 0, 0)
+	output := np.Round([]float64{diagram.Force, diagram.ForceDepth, diagram.TensionCrackDepth}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	expectedLayered := []float64{1.1111, 121.5909, 4.1415, 15}
+	layered := CalcActivePressure(layeredProfile, 6, 0.5, "rankine", 0, 0)
+	outputLayered := np.Round([]float64{layered.TensionCrackDepth, layered.Force, layered.ForceDepth, float64(len(layered.Depth))}, 4)
+	if reflect.DeepEqual(outputLayered, expectedLayered) == false {
+		t.Errorf("Expected %v, got %v", expectedLayered, outputLayered)
+	}
+}
+
+func TestGetDiagramDepths(t *testing.T) {
+	// a non-positive interval falls back to the default interval
+	depths, indices := GetDiagramDepths(layeredProfile, 6, 0)
+	expectedDepths, expectedIndices := GetDiagramDepths(layeredProfile, 6, DefaultDiagramInterval)
+	if !reflect.DeepEqual(depths, expectedDepths) || !reflect.DeepEqual(indices, expectedIndices) {
+		t.Errorf("Expected %v, got %v", expectedDepths, depths)
+	}
+}
+
+func TestCalcPassiveAndAtRestPressure(t *testing.T) {
+	expected := []float64{675, 3.3333, 112.5, 3.3333}
+	passive := CalcPassivePressure(sandProfile, 5, 0.5, "rankine", 0, 0)
+	atRest := CalcAtRestPressure(sandProfile, 5, 0.5)
+	output := np.Round([]float64{passive.Force, passive.ForceDepth, atRest.Force, atRest.ForceDepth}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcK0OC(t *testing.T) {
+	expected := []float64{0.5, 1}
+	output := np.Round([]float64{CalcK0OC(30, 1), CalcK0OC(30, 4)}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestMononobeOkabeCoefficients(t *testing.T) {
+	kh, kv := CalcSeismicCoefficients(ds.SeismicData{PGA: 0.4}, 0.5, 0)
+	expected := []float64{0.2, 0, 0.4733, 0.3333, 2.6291, 3}
+	output := np.Round([]float64{
+		kh,
+		kv,
+		CalcMononobeOkabeKae(30, 0, 0, kh, kv),
+		CalcMononobeOkabeKae(30, 0, 0, 0, 0),
+		CalcMononobeOkabeKpe(30, 0, 0, kh, kv),
+		CalcMononobeOkabeKpe(30, 0, 0, 0, 0),
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestMononobeOkabeCoefficients_NoEquilibrium(t *testing.T) {
+	output := []float64{
+		CalcMononobeOkabeKae(30, 0, 0, 0.7, 0),
+		CalcMononobeOkabeKae(30, 0, 10, 0.5, 0),
+		CalcMononobeOkabeKpe(30, 0, 0, 0.7, 0),
+		CalcMononobeOkabeActive(sandProfile, 5, 0, 0, 0.7, 0).DynamicIncrement,
+	}
+	for _, value := range output {
+		if !math.IsNaN(value) {
+			t.Errorf("Expected NaN, got %v", value)
+		}
+	}
+}
+
+func TestSeismicPressure(t *testing.T) {
+	results := []SeismicPressure{
+		CalcMononobeOkabeActive(sandProfile, 5, 0, 0, 0.2, 0),
+		CalcMononobeOkabePassive(sandProfile, 5, 0, 0, 0.2, 0),
+		CalcSeedWhitman(sandProfile, 5, 0, 0, 0.2),
+		CalcWood(sandProfile, 5, 0.2),
+	}
+	expectedIncrements := []float64{31.48, -83.45, 33.75, 90}
+	expectedHeights := []float64{3, 1.6667, 3, 2.9}
+	var outputIncrements, outputHeights []float64
+	for _, result := range results {
+		outputIncrements = append(outputIncrements, result.DynamicIncrement)
+		outputHeights = append(outputHeights, result.IncrementHeight)
+	}
+	if reflect.DeepEqual(np.Round(outputIncrements, 2), expectedIncrements) == false {
+		t.Errorf("Expected %v, got %v", expectedIncrements, outputIncrements)
+	}
+	if reflect.DeepEqual(np.Round(outputHeights, 4), expectedHeights) == false {
+		t.Errorf("Expected %v, got %v", expectedHeights, outputHeights)
+	}
+}
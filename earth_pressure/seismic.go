@@ -0,0 +1,141 @@
+package earth_pressure
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// SeismicPressure is a struct that contains the seismic earth pressure resultant on a wall per unit length and the
+// dynamic increment over the static resultant
+type SeismicPressure struct {
+	Method           string  `json:"method"`
+	Kh               float64 `json:"kh"`
+	Kv               float64 `json:"kv"`
+	Coefficient      float64 `json:"coefficient"`
+	StaticForce      float64 `json:"static_force"`
+	TotalForce       float64 `json:"total_force"`
+	DynamicIncrement float64 `json:"dynamic_increment"`
+	IncrementHeight  float64 `json:"increment_height"`
+}
+
+// CalcSeismicCoefficients returns the horizontal and vertical seismic coefficients from the peak ground
+// acceleration in g, where khFactor reduces PGA for walls that can yield and kvRatio is the ratio of kv to kh
+func CalcSeismicCoefficients(sd ds.SeismicData, khFactor, kvRatio float64) (float64, float64) {
+	kh := khFactor * sd.PGA
+	return kh, kvRatio * kh
+}
+
+// CalcMononobeOkabeKae returns the Mononobe-Okabe active seismic earth pressure coefficient of a vertical wall with
+// wall friction delta and backfill slope beta in degrees. It is NaN when the seismic inertia angle exceeds phi - beta
+// as the backfill cannot be in equilibrium.
+func CalcMononobeOkabeKae(phi, delta, beta, kh, kv float64) float64 {
+	psi := math.Atan(kh / (1 - kv))
+	phi, delta, beta = toRadians(phi), toRadians(delta), toRadians(beta)
+	if math.Sin(phi-psi-beta) < 0 {
+		return math.NaN()
+	}
+	root := math.Sqrt(math.Sin(phi+delta) * math.Sin(phi-psi-beta) / (math.Cos(delta+psi) * math.Cos(beta)))
+	return math.Pow(math.Cos(phi-psi), 2) / (math.Cos(psi) * math.Cos(delta+psi) * math.Pow(1+root, 2))
+}
+
+// CalcMononobeOkabeKpe returns the Mononobe-Okabe passive seismic earth pressure coefficient of a vertical wall with
+// wall friction delta and ground slope beta in degrees. It is NaN when the seismic inertia angle exceeds phi + beta
+// as the ground cannot be in equilibrium.
+func CalcMononobeOkabeKpe(phi, delta, beta, kh, kv float64) float64 {
+	psi := math.Atan(kh / (1 - kv))
+	phi, delta, beta = toRadians(phi), toRadians(delta), toRadians(beta)
+	if math.Sin(phi+beta-psi) < 0 {
+		return math.NaN()
+	}
+	root := math.Sqrt(math.Sin(phi+delta) * math.Sin(phi+beta-psi) / (math.Cos(delta+psi) * math.Cos(beta)))
+	return math.Pow(math.Cos(phi-psi), 2) / (math.Cos(psi) * math.Cos(delta+psi) * math.Pow(1-root, 2))
+}
+
+// GetBackfillProperties returns the average effective unit weight and the thickness weighted friction angle of
+// the soil profile over the wall height
+func GetBackfillProperties(sp ds.SoilProfile, H float64) (float64, float64) {
+	centers, thicknesses := settlement.GetSublayers(sp, 0, H, settlement.SublayerThickness)
+	var phi float64
+	for i, center := range centers {
+		phi += sp.Phi[sp.GetLayerIndex(center)] * thicknesses[i]
+	}
+	return sp.CalcEffectiveStress(H) / H, phi / H
+}
+
+// CalcMononobeOkabeActive returns the active seismic earth pressure on a vertical wall of height H by the
+// Mononobe-Okabe method. The dynamic increment acts at 0.6H above the base (Seed and Whitman, 1970).
+func CalcMononobeOkabeActive(sp ds.SoilProfile, H, delta, beta, kh, kv float64) SeismicPressure {
+	gamma, phi := GetBackfillProperties(sp, H)
+	Kae := CalcMononobeOkabeKae(phi, delta, beta, kh, kv)
+	staticForce := 0.5 * gamma * math.Pow(H, 2) * CalcCoulombKa(phi, delta, beta)
+	totalForce := 0.5 * gamma * math.Pow(H, 2) * (1 - kv) * Kae
+
+	return SeismicPressure{
+		Method:           "Mononobe-Okabe",
+		Kh:               kh,
+		Kv:               kv,
+		Coefficient:      Kae,
+		StaticForce:      staticForce,
+		TotalForce:       totalForce,
+		DynamicIncrement: totalForce - staticForce,
+		IncrementHeight:  0.6 * H,
+	}
+}
+
+// CalcMononobeOkabePassive returns the passive seismic earth pressure on a vertical wall embedded to depth H by the
+// Mononobe-Okabe method. The dynamic increment is negative as the earthquake reduces the passive resistance.
+func CalcMononobeOkabePassive(sp ds.SoilProfile, H, delta, beta, kh, kv float64) SeismicPressure {
+	gamma, phi := GetBackfillProperties(sp, H)
+	Kpe := CalcMononobeOkabeKpe(phi, delta, beta, kh, kv)
+	staticForce := 0.5 * gamma * math.Pow(H, 2) * CalcCoulombKp(phi, delta, beta)
+	totalForce := 0.5 * gamma * math.Pow(H, 2) * (1 - kv) * Kpe
+
+	return SeismicPressure{
+		Method:           "Mononobe-Okabe",
+		Kh:               kh,
+		Kv:               kv,
+		Coefficient:      Kpe,
+		StaticForce:      staticForce,
+		TotalForce:       totalForce,
+		DynamicIncrement: totalForce - staticForce,
+		IncrementHeight:  H / 3,
+	}
+}
+
+// CalcSeedWhitman returns the active seismic earth pressure on a vertical wall of height H by the simplified method
+// of Seed and Whitman (1970), where the dynamic increment coefficient is 0.75kh and acts at 0.6H above the base
+func CalcSeedWhitman(sp ds.SoilProfile, H, delta, beta, kh float64) SeismicPressure {
+	gamma, phi := GetBackfillProperties(sp, H)
+	staticForce := 0.5 * gamma * math.Pow(H, 2) * CalcCoulombKa(phi, delta, beta)
+	increment := 0.5 * gamma * math.Pow(H, 2) * 0.75 * kh
+
+	return SeismicPressure{
+		Method:           "Seed-Whitman",
+		Kh:               kh,
+		Coefficient:      0.75 * kh,
+		StaticForce:      staticForce,
+		TotalForce:       staticForce + increment,
+		DynamicIncrement: increment,
+		IncrementHeight:  0.6 * H,
+	}
+}
+
+// CalcWood returns the seismic earth pressure on a rigid non-yielding wall of height H by the simplified solution of
+// Wood (1973), where the dynamic increment is kh γ H² and acts at 0.58H above the base
+func CalcWood(sp ds.SoilProfile, H, kh float64) SeismicPressure {
+	gamma, phi := GetBackfillProperties(sp, H)
+	staticForce := 0.5 * gamma * math.Pow(H, 2) * CalcK0(phi)
+	increment := gamma * math.Pow(H, 2) * kh
+
+	return SeismicPressure{
+		Method:           "Wood",
+		Kh:               kh,
+		Coefficient:      kh,
+		StaticForce:      staticForce,
+		TotalForce:       staticForce + increment,
+		DynamicIncrement: increment,
+		IncrementHeight:  0.58 * H,
+	}
+}
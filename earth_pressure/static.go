@@ -0,0 +1,234 @@
+package earth_pressure
+
+import (
+	"math"
+	"sort"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// DefaultDiagramInterval is the sampling interval of the pressure diagrams used when the given interval is not
+// positive
+const DefaultDiagramInterval = 0.1
+
+// PressureDiagram is a struct that contains the lateral earth pressure distribution on a wall and its resultant
+// per unit length of the wall
+type PressureDiagram struct {
+	Depth             []float64 `json:"depth"`
+	Coefficient       []float64 `json:"coefficient"`
+	EffectivePressure []float64 `json:"effective_pressure"`
+	WaterPressure     []float64 `json:"water_pressure"`
+	TotalPressure     []float64 `json:"total_pressure"`
+	TensionCrackDepth float64   `json:"tension_crack_depth"`
+	Force             float64   `json:"force"`
+	ForceDepth        float64   `json:"force_depth"`
+	HorizontalForce   float64   `json:"horizontal_force"`
+	VerticalForce     float64   `json:"vertical_force"`
+}
+
+// toRadians converts the given angle in degrees to radians
+func toRadians(angle float64) float64 {
+	return angle * math.Pi / 180
+}
+
+// CalcRankineKa returns the Rankine active earth pressure coefficient for a backfill sloping at beta degrees
+func CalcRankineKa(phi, beta float64) float64 {
+	phi, beta = toRadians(phi), toRadians(beta)
+	root := math.Sqrt(math.Max(math.Pow(math.Cos(beta), 2)-math.Pow(math.Cos(phi), 2), 0))
+	return math.Cos(beta) * (math.Cos(beta) - root) / (math.Cos(beta) + root)
+}
+
+// CalcRankineKp returns the Rankine passive earth pressure coefficient for a backfill sloping at beta degrees
+func CalcRankineKp(phi, beta float64) float64 {
+	phi, beta = toRadians(phi), toRadians(beta)
+	root := math.Sqrt(math.Max(math.Pow(math.Cos(beta), 2)-math.Pow(math.Cos(phi), 2), 0))
+	return math.Cos(beta) * (math.Cos(beta) + root) / (math.Cos(beta) - root)
+}
+
+// CalcCoulombKa returns the Coulomb active earth pressure coefficient of a vertical wall with wall friction delta
+// and a backfill sloping at beta degrees
+func CalcCoulombKa(phi, delta, beta float64) float64 {
+	phi, delta, beta = toRadians(phi), toRadians(delta), toRadians(beta)
+	root := math.Sqrt(math.Sin(phi+delta) * math.Max(math.Sin(phi-beta), 0) / (math.Cos(delta) * math.Cos(beta)))
+	return math.Pow(math.Cos(phi), 2) / (math.Cos(delta) * math.Pow(1+root, 2))
+}
+
+// CalcCoulombKp returns the Coulomb passive earth pressure coefficient of a vertical wall with wall friction delta
+// and a ground surface sloping at beta degrees
+func CalcCoulombKp(phi, delta, beta float64) float64 {
+	phi, delta, beta = toRadians(phi), toRadians(delta), toRadians(beta)
+	root := math.Sqrt(math.Sin(phi+delta) * math.Sin(phi+beta) / (math.Cos(delta) * math.Cos(beta)))
+	return math.Pow(math.Cos(phi), 2) / (math.Cos(delta) * math.Pow(1-root, 2))
+}
+
+// CalcK0 returns the at-rest earth pressure coefficient of a normally consolidated soil (Jaky, 1944)
+func CalcK0(phi float64) float64 {
+	return 1 - math.Sin(toRadians(phi))
+}
+
+// CalcK0OC returns the at-rest earth pressure coefficient of an overconsolidated soil (Mayne and Kulhawy, 1982)
+func CalcK0OC(phi, OCR float64) float64 {
+	return CalcK0(phi) * math.Pow(OCR, math.Sin(toRadians(phi)))
+}
+
+// GetDiagramDepths returns the depths at which the pressure diagram is evaluated, which are sampled at the given
+// interval and include the depths where the pore pressure starts to increase and every layer boundary twice, once
+// for each layer. DefaultDiagramInterval is used when the interval is not positive.
+func GetDiagramDepths(sp ds.SoilProfile, H, dz float64) ([]float64, []int) {
+	if !(dz > 0) {
+		dz = DefaultDiagramInterval
+	}
+	depths := np.Arange(0, H, dz)
+	depths = append(depths, H)
+	for _, depth := range sp.GetWaterDepths() {
+		if depth < H {
+			depths = append(depths, depth)
+		}
+	}
+	layerDepths := sp.GetLayerDepths()
+	for _, depth := range layerDepths {
+		if depth < H {
+			depths = append(depths, depth)
+		}
+	}
+	depths = np.Unique(depths)
+	sort.Float64s(depths)
+
+	var diagramDepths []float64
+	var layerIndices []int
+	for _, depth := range depths {
+		layerIndex := sp.GetLayerIndex(depth)
+		diagramDepths = append(diagramDepths, depth)
+		layerIndices = append(layerIndices, layerIndex)
+		if depth == layerDepths[layerIndex] && layerIndex < len(layerDepths)-1 && depth < H {
+			diagramDepths = append(diagramDepths, depth)
+			layerIndices = append(layerIndices, layerIndex+1)
+		}
+	}
+	return diagramDepths, layerIndices
+}
+
+// calcRawPressures returns the effective lateral pressures at the given depths, which are negative in the tension
+// zone of cohesive soils under active conditions
+func calcRawPressures(sp ds.SoilProfile, depths []float64, layerIndices []int, coefficient func(int) float64, sign float64) []float64 {
+	var pressures []float64
+	for i, depth := range depths {
+		K := coefficient(layerIndices[i])
+		c := sp.GetCohesion(layerIndices[i])
+		pressures = append(pressures, K*sp.CalcEffectiveStress(depth)+sign*2*c*math.Sqrt(K))
+	}
+	return pressures
+}
+
+// calcTensionCrackDepth returns the depth of the zone of negative lateral pressures starting from the top of the wall
+func calcTensionCrackDepth(depths []float64, layerIndices []int, pressures []float64) float64 {
+	if len(pressures) == 0 || pressures[0] >= 0 {
+		return 0
+	}
+	for i := 1; i < len(pressures); i++ {
+		if pressures[i] >= 0 {
+			if layerIndices[i] != layerIndices[i-1] {
+				return depths[i]
+			}
+			return depths[i-1] + (depths[i]-depths[i-1])*pressures[i-1]/(pressures[i-1]-pressures[i])
+		}
+	}
+	return depths[len(depths)-1]
+}
+
+// calcPressureDiagram returns the pressure diagram for the given earth pressure coefficient function of the layers.
+// sign is -1 for active and +1 for passive pressures, and the resultant acts at the given angle to the normal of
+// the wall.
+func calcPressureDiagram(sp ds.SoilProfile, H, dz float64, coefficient func(int) float64, sign, angle float64) PressureDiagram {
+	depths, layerIndices := GetDiagramDepths(sp, H, dz)
+	rawPressures := calcRawPressures(sp, depths, layerIndices, coefficient, sign)
+	crackDepth := calcTensionCrackDepth(depths, layerIndices, rawPressures)
+
+	// the bottom of the tension crack is added to the diagram so that the resultant is integrated exactly
+	for i := 1; i < len(depths); i++ {
+		if crackDepth > depths[i-1] && crackDepth < depths[i] {
+			depths = append(depths[:i], append([]float64{crackDepth}, depths[i:]...)...)
+			layerIndices = append(layerIndices[:i], append([]int{layerIndices[i]}, layerIndices[i:]...)...)
+			rawPressures = calcRawPressures(sp, depths, layerIndices, coefficient, sign)
+			break
+		}
+	}
+
+	diagram := PressureDiagram{TensionCrackDepth: crackDepth}
+	for i, depth := range depths {
+		sigma := sp.CalcEffectiveStress(depth)
+		u := sp.CalcNormalStress(depth) - sigma
+		pressure := math.Max(rawPressures[i], 0)
+
+		diagram.Depth = append(diagram.Depth, depth)
+		diagram.Coefficient = append(diagram.Coefficient, coefficient(layerIndices[i]))
+		diagram.EffectivePressure = append(diagram.EffectivePressure, pressure)
+		diagram.WaterPressure = append(diagram.WaterPressure, u)
+		diagram.TotalPressure = append(diagram.TotalPressure, pressure+u)
+	}
+
+	var force, moment float64
+	for i := 1; i < len(depths); i++ {
+		dh := depths[i] - depths[i-1]
+		p0, p1 := diagram.TotalPressure[i-1], diagram.TotalPressure[i]
+		F := (p0 + p1) / 2 * dh
+		if F > 0 {
+			// centroid of the trapezoid from its top
+			centroid := dh * (p0 + 2*p1) / (3 * (p0 + p1))
+			moment += F * (depths[i-1] + centroid)
+		}
+		force += F
+	}
+	diagram.Force = force
+	if force > 0 {
+		diagram.ForceDepth = moment / force
+	}
+	diagram.HorizontalForce = force * math.Cos(toRadians(angle))
+	diagram.VerticalForce = force * math.Sin(toRadians(angle))
+	return diagram
+}
+
+// CalcActivePressure returns the active earth pressure diagram on a vertical wall of height H by the rankine or
+// coulomb method, with wall friction delta and backfill slope beta in degrees
+func CalcActivePressure(sp ds.SoilProfile, H, dz float64, method string, delta, beta float64) PressureDiagram {
+	coefficient := func(layerIndex int) float64 {
+		if method == "coulomb" {
+			return CalcCoulombKa(sp.Phi[layerIndex], delta, beta)
+		}
+		return CalcRankineKa(sp.Phi[layerIndex], beta)
+	}
+	angle := beta
+	if method == "coulomb" {
+		angle = delta
+	}
+	return calcPressureDiagram(sp, H, dz, coefficient, -1, angle)
+}
+
+// CalcPassivePressure returns the passive earth pressure diagram on a vertical wall embedded to depth H by the
+// rankine or coulomb method, with wall friction delta and ground slope beta in degrees
+func CalcPassivePressure(sp ds.SoilProfile, H, dz float64, method string, delta, beta float64) PressureDiagram {
+	coefficient := func(layerIndex int) float64 {
+		if method == "coulomb" {
+			return CalcCoulombKp(sp.Phi[layerIndex], delta, beta)
+		}
+		return CalcRankineKp(sp.Phi[layerIndex], beta)
+	}
+	angle := beta
+	if method == "coulomb" {
+		angle = delta
+	}
+	return calcPressureDiagram(sp, H, dz, coefficient, 1, angle)
+}
+
+// CalcAtRestPressure returns the at-rest earth pressure diagram on a vertical wall of height H, where the OCR of the
+// layers increases the at-rest coefficient
+func CalcAtRestPressure(sp ds.SoilProfile, H, dz float64) PressureDiagram {
+	coefficient := func(layerIndex int) float64 {
+		if len(sp.OCR) > layerIndex && sp.OCR[layerIndex] > 1 {
+			return CalcK0OC(sp.Phi[layerIndex], sp.OCR[layerIndex])
+		}
+		return CalcK0(sp.Phi[layerIndex])
+	}
+	return calcPressureDiagram(sp, H, dz, coefficient, 0, 0)
+}
@@ -0,0 +1,176 @@
+package embedded_wall
+
+import (
+	"math"
+	"sort"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/earth_pressure"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// DepthInterval is the depth interval at which the net pressure on the wall is evaluated
+const DepthInterval = 0.05
+
+// FixedEarthFactor is the increase applied to the depth of the point of rotation of a cantilever wall to account
+// for the reaction below it (simplified fixed-earth method)
+const FixedEarthFactor = 1.2
+
+// EmbeddedWall is a struct that contains the design of an embedded wall per unit length. Pressures act towards the
+// excavation when positive and the depths are measured from the top of the wall on the retained side.
+type EmbeddedWall struct {
+	Method          string    `json:"method"`
+	ExcavationDepth float64   `json:"excavation_depth"`
+	Depth           []float64 `json:"depth"`
+	NetPressure     []float64 `json:"net_pressure"`
+	BendingMoment   []float64 `json:"bending_moment"`
+	PivotDepth      float64   `json:"pivot_depth"`
+	EmbedmentDepth  float64   `json:"embedment_depth"`
+	MaxMoment       float64   `json:"max_moment"`
+	MaxMomentDepth  float64   `json:"max_moment_depth"`
+	AnchorForce     float64   `json:"anchor_force"`
+	ToeReaction     float64   `json:"toe_reaction"`
+	Converged       bool      `json:"converged"`
+}
+
+// GetWallDepths returns the depths at which the net pressure is evaluated down to the bottom of the soil profile,
+// including the excavation level and the depths where the pore pressure starts to increase
+func GetWallDepths(sp ds.SoilProfile, H float64) []float64 {
+	layerDepths := sp.GetLayerDepths()
+	bottom := layerDepths[len(layerDepths)-1]
+	depths := append(np.Arange(0, bottom, DepthInterval), bottom, H)
+	depths = append(depths, sp.GetWaterDepths()...)
+	depths = np.Unique(depths)
+	sort.Float64s(depths)
+	return depths
+}
+
+// CalcActiveWallPressure returns the active pressure on the retained side of the wall at the given depth by the
+// Rankine method, including the pore water pressure
+func CalcActiveWallPressure(sp ds.SoilProfile, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	Ka := earth_pressure.CalcRankineKa(sp.Phi[layerIndex], 0)
+	c := sp.GetCohesion(layerIndex)
+	pressure := Ka*sp.CalcEffectiveStress(depth) - 2*c*math.Sqrt(Ka)
+	return math.Max(pressure, 0) + sp.CalcPorePressure(depth)
+}
+
+// CalcPassiveWallPressure returns the passive pressure on the excavation side of the wall at the given depth by the
+// Rankine method divided by passiveFS, including the pore water pressure. The water level on the excavation side
+// is at the excavation level or at the water table of the retained side if deeper, and there is no seepage.
+func CalcPassiveWallPressure(sp ds.SoilProfile, H, depth, passiveFS float64) float64 {
+	if depth <= H {
+		return 0
+	}
+	layerIndex := sp.GetLayerIndex(depth)
+	Kp := earth_pressure.CalcRankineKp(sp.Phi[layerIndex], 0)
+	c := sp.GetCohesion(layerIndex)
+	u := sp.CalcPorePressure(depth) - sp.CalcPorePressure(H)
+	sigma := sp.CalcNormalStress(depth) - sp.CalcNormalStress(H) - u
+	return (Kp*sigma+2*c*math.Sqrt(Kp))/passiveFS + u
+}
+
+// calcNetPressures returns the net pressures on the wall and their cumulative force and first moment about the top
+func calcNetPressures(sp ds.SoilProfile, H, passiveFS float64, depths []float64) ([]float64, []float64, []float64) {
+	var pressures []float64
+	for _, depth := range depths {
+		pa := CalcActiveWallPressure(sp, depth)
+		pp := CalcPassiveWallPressure(sp, H, depth, passiveFS)
+		pressures = append(pressures, pa-pp)
+	}
+
+	force := []float64{0}
+	moment := []float64{0}
+	for i := 1; i < len(depths); i++ {
+		dh := depths[i] - depths[i-1]
+		force = append(force, force[i-1]+(pressures[i-1]+pressures[i])/2*dh)
+		moment = append(moment, moment[i-1]+(pressures[i-1]*depths[i-1]+pressures[i]*depths[i])/2*dh)
+	}
+	return pressures, force, moment
+}
+
+// findRoot returns the depth below the excavation level at which the given function of depth changes sign from
+// positive to negative and whether such a depth exists
+func findRoot(depths []float64, H float64, f func(int) float64) (float64, bool) {
+	for i := 1; i < len(depths); i++ {
+		if depths[i] <= H {
+			continue
+		}
+		f0, f1 := f(i-1), f(i)
+		if f0 > 0 && f1 <= 0 {
+			return depths[i-1] + (depths[i]-depths[i-1])*f0/(f0-f1), true
+		}
+	}
+	return depths[len(depths)-1], false
+}
+
+// calcBendingMoments returns the bending moment diagram of the wall down to the given depth, with an anchor force T
+// at the anchor depth
+func calcBendingMoments(wall *EmbeddedWall, depths, pressures, force, moment []float64, bottom, T, anchorDepth float64) {
+	for i, depth := range depths {
+		if depth > bottom {
+			break
+		}
+		M := depth*force[i] - moment[i]
+		if depth > anchorDepth {
+			M -= T * (depth - anchorDepth)
+		}
+		wall.Depth = append(wall.Depth, depth)
+		wall.NetPressure = append(wall.NetPressure, pressures[i])
+		wall.BendingMoment = append(wall.BendingMoment, M)
+		if math.Abs(M) > math.Abs(wall.MaxMoment) {
+			wall.MaxMoment = M
+			wall.MaxMomentDepth = depth
+		}
+	}
+}
+
+// CalcCantileverWall returns the design of a cantilever wall retaining an excavation of depth H by the simplified
+// fixed-earth method. The wall rotates about a point at PivotDepth below the excavation level, where the moments of
+// the net pressures above it are in equilibrium, and the embedment is increased by FixedEarthFactor to mobilize the
+// reaction below the pivot.
+func CalcCantileverWall(sp ds.SoilProfile, H, passiveFS float64) EmbeddedWall {
+	depths := GetWallDepths(sp, H)
+	pressures, force, moment := calcNetPressures(sp, H, passiveFS, depths)
+
+	// moment of the net pressures about the pivot
+	pivot, converged := findRoot(depths, H, func(i int) float64 {
+		return depths[i]*force[i] - moment[i]
+	})
+	toeReaction := -np.Interp([]float64{pivot}, depths, force)[0]
+
+	wall := EmbeddedWall{
+		Method:          "fixed-earth",
+		PivotDepth:      pivot - H,
+		EmbedmentDepth:  FixedEarthFactor * (pivot - H),
+		ToeReaction:     toeReaction,
+		Converged:       converged,
+		ExcavationDepth: H,
+	}
+	calcBendingMoments(&wall, depths, pressures, force, moment, pivot, 0, pivot)
+	return wall
+}
+
+// CalcAnchoredWall returns the design of a single anchored wall retaining an excavation of depth H by the
+// free-earth support method, where the embedment is the depth at which the moments of the net pressures about the
+// anchor are in equilibrium and the anchor force balances the net pressures
+func CalcAnchoredWall(sp ds.SoilProfile, H, anchorDepth, passiveFS float64) EmbeddedWall {
+	depths := GetWallDepths(sp, H)
+	pressures, force, moment := calcNetPressures(sp, H, passiveFS, depths)
+
+	// moment of the net pressures about the anchor
+	toe, converged := findRoot(depths, H, func(i int) float64 {
+		return moment[i] - anchorDepth*force[i]
+	})
+	anchorForce := np.Interp([]float64{toe}, depths, force)[0]
+
+	wall := EmbeddedWall{
+		Method:          "free-earth",
+		EmbedmentDepth:  toe - H,
+		AnchorForce:     anchorForce,
+		Converged:       converged,
+		ExcavationDepth: H,
+	}
+	calcBendingMoments(&wall, depths, pressures, force, moment, toe, anchorForce, anchorDepth)
+	return wall
+}
@@ -0,0 +1,59 @@
+package embedded_wall
+
+import (
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var sandProfile = ds.SoilProfile{
+	SoilClass:           []string{"SP"},
+	Thickness:           []float64{20},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{20},
+	Phi:                 []float64{30},
+	Gwt:                 20,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var layeredProfile = ds.SoilProfile{
+	SoilClass:           []string{"SM", "SP"},
+	Thickness:           []float64{3, 17},
+	DryUnitWeight:       []float64{18, 19},
+	SaturatedUnitWeight: []float64{20, 20},
+	Phi:                 []float64{30, 34},
+	Gwt:                 2,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+func TestCalcCantileverWall(t *testing.T) {
+	// the pivot of a dry sand is at H / ((Kp/Ka)^(1/3) - 1) = 3.7034 below the excavation
+	output := CalcCantileverWall(sandProfile, 4, 1)
+	expected := []float64{3.7, 4.44, 144.03, 6}
+	result := np.Round([]float64{output.PivotDepth, output.EmbedmentDepth, output.MaxMoment, output.MaxMomentDepth}, 2)
+	if !reflect.DeepEqual(expected, result) || !output.Converged {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCalcAnchoredWall(t *testing.T) {
+	output := CalcAnchoredWall(sandProfile, 4, 0, 1)
+	expected := []float64{1.6, 24.78, -47.49}
+	result := np.Round([]float64{output.EmbedmentDepth, output.AnchorForce, output.MaxMoment}, 2)
+	if !reflect.DeepEqual(expected, result) || !output.Converged {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	output = CalcAnchoredWall(layeredProfile, 5, 1, 1.5)
+	expected = []float64{4.76, 91.66, -215.55}
+	result = np.Round([]float64{output.EmbedmentDepth, output.AnchorForce, output.MaxMoment}, 2)
+	if !reflect.DeepEqual(expected, result) || !output.Converged {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,133 @@
+package foundation_stability
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+)
+
+// BasePressure is a struct that contains the contact pressure distribution under the foundation in one direction
+type BasePressure struct {
+	Shape         string  `json:"shape"`
+	MaxPressure   float64 `json:"max_pressure"`
+	MinPressure   float64 `json:"min_pressure"`
+	ContactLength float64 `json:"contact_length"`
+	Uplift        bool    `json:"uplift"`
+	Overturning   bool    `json:"overturning"` //the resultant is outside the base
+}
+
+// BasePressureDistribution is a struct that contains the contact pressure distributions along B and along L
+type BasePressureDistribution struct {
+	X BasePressure `json:"x"`
+	Y BasePressure `json:"y"`
+}
+
+// OverturningResult is a struct that contains the overturning check of a foundation in one direction
+type OverturningResult struct {
+	OverturningMoment float64 `json:"overturning_moment"`
+	ResistingMoment   float64 `json:"resisting_moment"`
+	SafetyFactor      float64 `json:"safety_factor"`
+}
+
+// OverturningCheck is a struct that contains the overturning checks of a foundation about the edges parallel to L
+// and parallel to B
+type OverturningCheck struct {
+	X OverturningResult `json:"x"`
+	Y OverturningResult `json:"y"`
+}
+
+// CalcBaseMoments returns the moments at the foundation base in the directions of B and L, including the moments
+// of the horizontal loads acting at the top of the foundation
+func CalcBaseMoments(bd ds.BuildingData) (float64, float64) {
+	return bd.Mx + bd.Vx*bd.Df, bd.My + bd.Vy*bd.Df
+}
+
+// CalcEccentricity returns the eccentricities of the vertical load in the directions of B and L, which are zero when
+// there is no vertical load
+func CalcEccentricity(bd ds.BuildingData) (float64, float64) {
+	N := CalcVerticalLoad(bd)
+	if N <= 0 {
+		return 0, 0
+	}
+	MB, ML := CalcBaseMoments(bd)
+	return MB / N, ML / N
+}
+
+// CalcEffectiveDimensions returns the effective width and length of the foundation (Meyerhof, 1953), which are zero
+// when the resultant is outside the base
+func CalcEffectiveDimensions(bd ds.BuildingData) (float64, float64) {
+	eB, eL := CalcEccentricity(bd)
+	return math.Max(bd.B-2*math.Abs(eB), 0), math.Max(bd.L-2*math.Abs(eL), 0)
+}
+
+// CalcEffectiveArea returns the effective area of the foundation
+func CalcEffectiveArea(bd ds.BuildingData) float64 {
+	B, L := CalcEffectiveDimensions(bd)
+	return B * L
+}
+
+// CalcBasePressure returns the contact pressure distribution of a rigid foundation with the given dimensions under
+// a vertical load N with eccentricity e in the direction of width. The distribution is trapezoidal when the load is
+// within the kern and triangular with uplift of the heel otherwise. The foundation overturns when the resultant is
+// outside the base, which is reported without pressures since there is no equilibrium.
+func CalcBasePressure(N, e, width, length float64) BasePressure {
+	e = math.Abs(e)
+	if e >= width/2 {
+		return BasePressure{Shape: "overturning", Uplift: true, Overturning: true}
+	}
+	if e <= width/6 {
+		qAvg := N / (width * length)
+		shape := "trapezoidal"
+		if e == width/6 {
+			shape = "triangular"
+		}
+		return BasePressure{
+			Shape:         shape,
+			MaxPressure:   qAvg * (1 + 6*e/width),
+			MinPressure:   qAvg * (1 - 6*e/width),
+			ContactLength: width,
+		}
+	}
+
+	contactLength := 3 * (width/2 - e)
+	return BasePressure{
+		Shape:         "triangular",
+		MaxPressure:   2 * N / (contactLength * length),
+		MinPressure:   0,
+		ContactLength: contactLength,
+		Uplift:        true,
+	}
+}
+
+// CalcBasePressureDistribution returns the contact pressure distributions of the foundation along B and along L.
+// Each direction is treated with its own eccentricity only, the biaxial distribution under both eccentricities
+// acting together is not considered.
+func CalcBasePressureDistribution(bd ds.BuildingData) BasePressureDistribution {
+	N := CalcVerticalLoad(bd)
+	eB, eL := CalcEccentricity(bd)
+	return BasePressureDistribution{
+		X: CalcBasePressure(N, eB, bd.B, bd.L),
+		Y: CalcBasePressure(N, eL, bd.L, bd.B),
+	}
+}
+
+// calcOverturningResult returns the overturning check about the edge of a foundation with given width
+func calcOverturningResult(N, M, width float64) OverturningResult {
+	resistingMoment := N * width / 2
+	overturningMoment := math.Abs(M)
+	return OverturningResult{
+		OverturningMoment: overturningMoment,
+		ResistingMoment:   resistingMoment,
+		SafetyFactor:      resistingMoment / overturningMoment,
+	}
+}
+
+// CheckOverturning returns the overturning checks of the foundation about its edges
+func CheckOverturning(bd ds.BuildingData) OverturningCheck {
+	N := CalcVerticalLoad(bd)
+	MB, ML := CalcBaseMoments(bd)
+	return OverturningCheck{
+		X: calcOverturningResult(N, MB, bd.B),
+		Y: calcOverturningResult(N, ML, bd.L),
+	}
+}
@@ -0,0 +1,128 @@
+package foundation_stability
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var soilProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL", "CH"},
+	Thickness:           []float64{1.5, 8.5},
+	DryUnitWeight:       []float64{18, 18},
+	SaturatedUnitWeight: []float64{19, 19},
+	Phi:                 []float64{25, 30},
+	Cohesion:            []float64{5, 0},
+	Cu:                  []float64{40, 60},
+	Gwt:                 10,
+	CheckGwt:            true,
+}
+
+var buildingData = ds.BuildingData{
+	Df:                  1,
+	B:                   2,
+	L:                   3,
+	Q:                   100,
+	Vx:                  150,
+	Vy:                  100,
+	FrictionCoefficient: 0.45,
+}
+
+func TestCheckSliding(t *testing.T) {
+	expectedDrained := []float64{2.1787, 3.0787, 1.8764}
+	expectedUndrained := []float64{2.49, 3.29, 2.2213}
+
+	drained := CheckSlidingDrained(soilProfile, buildingData, 0.5)
+	undrained := CheckSlidingUndrained(soilProfile, buildingData, 0.5)
+	outputDrained := np.Round([]float64{drained.X.SafetyFactor, drained.Y.SafetyFactor, drained.Resultant.SafetyFactor}, 4)
+	outputUndrained := np.Round([]float64{undrained.X.SafetyFactor, undrained.Y.SafetyFactor, undrained.Resultant.SafetyFactor}, 4)
+
+	if reflect.DeepEqual(outputDrained, expectedDrained) == false {
+		t.Errorf("Expected %v, got %v", expectedDrained, outputDrained)
+	}
+	if reflect.DeepEqual(outputUndrained, expectedUndrained) == false {
+		t.Errorf("Expected %v, got %v", expectedUndrained, outputUndrained)
+	}
+}
+
+func TestCheckSliding_NoLoad(t *testing.T) {
+	bd := buildingData
+	bd.Df, bd.Q, bd.Vx, bd.Vy = 0, 0, 0, 0
+	expected := []float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+
+	for _, result := range []SlidingCheck{CheckSlidingDrained(soilProfile, bd, 0.5), CheckSlidingUndrained(soilProfile, bd, 0.5)} {
+		output := []float64{result.X.SafetyFactor, result.Y.SafetyFactor, result.Resultant.SafetyFactor}
+		if reflect.DeepEqual(output, expected) == false {
+			t.Errorf("Expected %v, got %v", expected, output)
+		}
+	}
+}
+
+func TestEccentricity(t *testing.T) {
+	bd := buildingData
+	bd.My = 500
+
+	expected := []float64{0.25, 1, 1.5, 1, 1.5}
+	eB, eL := CalcEccentricity(bd)
+	B, L := CalcEffectiveDimensions(bd)
+	output := np.Round([]float64{eB, eL, B, L, CalcEffectiveArea(bd)}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestEccentricity_NoLoad(t *testing.T) {
+	bd := buildingData
+	bd.Q, bd.My = 0, 500
+
+	expected := []float64{0, 0, 2, 3}
+	eB, eL := CalcEccentricity(bd)
+	B, L := CalcEffectiveDimensions(bd)
+	output := []float64{eB, eL, B, L}
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcBasePressureDistribution(t *testing.T) {
+	bd := buildingData
+	bd.My = 500
+
+	expected := BasePressureDistribution{
+		X: BasePressure{Shape: "trapezoidal", MaxPressure: 175, MinPressure: 25, ContactLength: 2},
+		Y: BasePressure{Shape: "triangular", MaxPressure: 400, MinPressure: 0, ContactLength: 1.5, Uplift: true},
+	}
+	output := CalcBasePressureDistribution(bd)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcBasePressure_Overturning(t *testing.T) {
+	bd := buildingData
+	bd.My = 1000
+
+	expected := BasePressure{Shape: "overturning", Uplift: true, Overturning: true}
+	output := CalcBasePressureDistribution(bd).Y
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+	if _, L := CalcEffectiveDimensions(bd); L != 0 {
+		t.Errorf("Expected %v, got %v", 0, L)
+	}
+}
+
+func TestCheckOverturning(t *testing.T) {
+	bd := buildingData
+	bd.My = 500
+
+	expected := []float64{4, 1.5}
+	check := CheckOverturning(bd)
+	output := np.Round([]float64{check.X.SafetyFactor, check.Y.SafetyFactor}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
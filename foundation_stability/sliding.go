@@ -0,0 +1,125 @@
+package foundation_stability
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/earth_pressure"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// SlidingResult is a struct that contains the sliding check of a foundation in one direction
+type SlidingResult struct {
+	HorizontalLoad    float64 `json:"horizontal_load"`
+	BaseResistance    float64 `json:"base_resistance"`
+	PassiveResistance float64 `json:"passive_resistance"`
+	SafetyFactor      float64 `json:"safety_factor"`
+}
+
+// SlidingCheck is a struct that contains the sliding checks of a foundation along B, along L and along the
+// direction of the resultant horizontal load
+type SlidingCheck struct {
+	X         SlidingResult `json:"x"`
+	Y         SlidingResult `json:"y"`
+	Resultant SlidingResult `json:"resultant"`
+}
+
+// CalcVerticalLoad returns the total vertical load of the foundation from the foundation pressure
+func CalcVerticalLoad(bd ds.BuildingData) float64 {
+	return bd.Q * bd.B * bd.L
+}
+
+// CalcPassiveResistance returns the passive resistance per unit width mobilized on the embedded depth of the
+// foundation. Drained resistance uses effective stresses with c' and phi', undrained resistance uses total stresses
+// with Cu. The strength parameters which are not given are taken as zero.
+func CalcPassiveResistance(sp ds.SoilProfile, Df float64, drained bool) float64 {
+	if Df <= 0 {
+		return 0
+	}
+	centers, thicknesses := settlement.GetSublayers(sp, 0, Df, settlement.SublayerThickness)
+
+	var Pp float64
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		var sigmaP float64
+		if drained {
+			var phi float64
+			if len(sp.Phi) > layerIndex {
+				phi = sp.Phi[layerIndex]
+			}
+			Kp := earth_pressure.CalcRankineKp(phi, 0)
+			if len(sp.Kp) > layerIndex && sp.Kp[layerIndex] > 0 {
+				Kp = sp.Kp[layerIndex]
+			}
+			var c float64
+			if len(sp.Cohesion) > layerIndex {
+				c = sp.Cohesion[layerIndex]
+			}
+			sigmaP = Kp*sp.CalcEffectiveStress(center) + 2*c*math.Sqrt(Kp)
+		} else {
+			sigmaP = sp.CalcNormalStress(center) + 2*getCu(sp, layerIndex)
+		}
+		Pp += sigmaP * thicknesses[i]
+	}
+	return Pp
+}
+
+// getCu returns the undrained shear strength of the layer with the given index, or zero if it is not given
+func getCu(sp ds.SoilProfile, layerIndex int) float64 {
+	if len(sp.Cu) > layerIndex {
+		return sp.Cu[layerIndex]
+	}
+	return 0
+}
+
+// calcSlidingResult returns the sliding check for the given loads and resistances. The safety factor is infinite
+// when there is no horizontal load.
+func calcSlidingResult(H, baseResistance, passiveResistance float64) SlidingResult {
+	SF := math.Inf(1)
+	if H > 0 {
+		SF = (baseResistance + passiveResistance) / H
+	}
+	return SlidingResult{
+		HorizontalLoad:    H,
+		BaseResistance:    baseResistance,
+		PassiveResistance: passiveResistance,
+		SafetyFactor:      SF,
+	}
+}
+
+// checkSliding returns the sliding checks for the given base resistance. The passive resistance acts on the face of
+// length L for loads along B and on the face of length B for loads along L, and is reduced by passiveFactor to
+// account for the displacement needed to mobilize it.
+func checkSliding(sp ds.SoilProfile, bd ds.BuildingData, baseResistance, passiveFactor float64, drained bool) SlidingCheck {
+	Pp := passiveFactor * CalcPassiveResistance(sp, bd.Df, drained)
+	PpX := Pp * bd.L
+	PpY := Pp * bd.B
+	H := math.Hypot(bd.Vx, bd.Vy)
+
+	// passive resistance of both faces projected on the direction of the resultant load
+	var PpResultant float64
+	if H > 0 {
+		PpResultant = PpX*math.Abs(bd.Vx)/H + PpY*math.Abs(bd.Vy)/H
+	}
+
+	return SlidingCheck{
+		X:         calcSlidingResult(math.Abs(bd.Vx), baseResistance, PpX),
+		Y:         calcSlidingResult(math.Abs(bd.Vy), baseResistance, PpY),
+		Resultant: calcSlidingResult(H, baseResistance, PpResultant),
+	}
+}
+
+// CheckSlidingDrained returns the sliding checks of the foundation for drained base conditions, where the base
+// resistance is the vertical load multiplied by the friction coefficient of the base
+func CheckSlidingDrained(sp ds.SoilProfile, bd ds.BuildingData, passiveFactor float64) SlidingCheck {
+	baseResistance := CalcVerticalLoad(bd) * bd.FrictionCoefficient
+	return checkSliding(sp, bd, baseResistance, passiveFactor, true)
+}
+
+// CheckSlidingUndrained returns the sliding checks of the foundation for undrained base conditions, where the base
+// resistance is the undrained shear strength of the bearing layer acting on the base area
+func CheckSlidingUndrained(sp ds.SoilProfile, bd ds.BuildingData, passiveFactor float64) SlidingCheck {
+	layerIndex := sp.GetLayerIndex(bd.Df + 1e-6)
+	baseResistance := getCu(sp, layerIndex) * bd.B * bd.L
+	return checkSliding(sp, bd, baseResistance, passiveFactor, false)
+}
@@ -0,0 +1,257 @@
+package piles
+
+import (
+	"math"
+	"strings"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// zoneSamples is the number of points used to average field test results within the tip zone of a pile
+const zoneSamples = 20
+
+// GetConeResistance returns the cone resistance at the given depth by linear interpolation of the CPT log
+func GetConeResistance(cptLog ds.CPTData, depth float64) float64 {
+	return np.Interp([]float64{depth}, cptLog.Depth, cptLog.ConeResistance)[0]
+}
+
+// GetEffectiveConeResistance returns the cone resistance corrected for the pore pressure at the given depth
+func GetEffectiveConeResistance(cptLog ds.CPTData, depth float64) float64 {
+	qc := GetConeResistance(cptLog, depth)
+	if len(cptLog.PorePressure) != len(cptLog.Depth) {
+		return qc
+	}
+	u2 := np.Interp([]float64{depth}, cptLog.Depth, cptLog.PorePressure)[0]
+	return math.Max(qc-u2, 0)
+}
+
+// sampleZone returns the values of f at equally spaced points between the given levels
+func sampleZone(f func(float64) float64, top, bottom float64) []float64 {
+	top = math.Max(top, 0)
+	var values []float64
+	for i := 0; i <= zoneSamples; i++ {
+		values = append(values, f(top+(bottom-top)*float64(i)/zoneSamples))
+	}
+	return values
+}
+
+// calcGeometricMean returns the geometric mean of the given values
+func calcGeometricMean(values []float64) float64 {
+	var sumLog float64
+	for _, value := range values {
+		sumLog += math.Log(math.Max(value, 1e-9))
+	}
+	return math.Exp(sumLog / float64(len(values)))
+}
+
+// getFieldTestDepths returns the capacity curve depths of the pile that are covered by the field test
+func getFieldTestDepths(sp ds.SoilProfile, pile ds.PileData, maxTestDepth float64) []float64 {
+	var depths []float64
+	for _, depth := range GetCapacityDepths(sp, pile) {
+		if depth <= maxTestDepth {
+			depths = append(depths, depth)
+		}
+	}
+	return depths
+}
+
+// calcFieldTestCapacity returns the axial capacity of the pile versus depth for the given unit shaft friction and
+// unit base resistance functions
+func calcFieldTestCapacity(sp ds.SoilProfile, pile ds.PileData, depths []float64, unitShaft func(float64) float64, unitBase func(float64) float64, FS float64) AxialCapacity {
+	perimeter := CalcPerimeter(pile)
+	area := CalcBaseArea(pile)
+
+	var capacity AxialCapacity
+	for _, depth := range depths {
+		centers, thicknesses := settlement.GetSublayers(sp, 0, depth, settlement.SublayerThickness)
+		var Qs float64
+		for i, center := range centers {
+			Qs += unitShaft(center) * perimeter * thicknesses[i]
+		}
+		Qb := unitBase(depth) * area
+		capacity.Depth = append(capacity.Depth, depth)
+		capacity.ShaftResistance = append(capacity.ShaftResistance, Qs)
+		capacity.BaseResistance = append(capacity.BaseResistance, Qb)
+		capacity.UltimateCapacity = append(capacity.UltimateCapacity, Qs+Qb)
+		capacity.AllowableCapacity = append(capacity.AllowableCapacity, (Qs+Qb)/FS)
+	}
+	return capacity
+}
+
+// CalcSPTCapacityMeyerhof returns the axial capacity of the pile versus depth by Meyerhof (1976). The base blow
+// count is averaged from 10D above to 4D below the tip and the resistances of bored piles are taken as one third of
+// the base and one half of the shaft resistances of driven piles.
+func CalcSPTCapacityMeyerhof(sp ds.SoilProfile, pile ds.PileData, sptLog ds.SPTData, FS float64) AxialCapacity {
+	D := pile.Diameter
+	shaftFactor, baseFactor := 2.0, 40.0
+	if isBored(pile) {
+		shaftFactor, baseFactor = 1.0, 40.0/3
+	}
+	NAt := func(depth float64) float64 { return sptLog.GetN60(depth, sp.LengthUnit()) }
+
+	unitShaft := func(depth float64) float64 {
+		return shaftFactor * NAt(depth) * sp.AtmosphericPressure() / 100
+	}
+	unitBase := func(depth float64) float64 {
+		N := np.Mean(sampleZone(NAt, depth-10*D, depth+4*D))
+		qb := baseFactor * N * depth / D * sp.AtmosphericPressure() / 100
+		return math.Min(qb, 10*baseFactor*N*sp.AtmosphericPressure()/100)
+	}
+
+	depths := getFieldTestDepths(sp, pile, sptLog.Depth[len(sptLog.Depth)-1])
+	return calcFieldTestCapacity(sp, pile, depths, unitShaft, unitBase, FS)
+}
+
+// CalcSPTCapacityDecourt returns the axial capacity of the pile versus depth by Decourt (1995). The base blow count
+// is averaged from 1 m above to 1 m below the tip.
+func CalcSPTCapacityDecourt(sp ds.SoilProfile, pile ds.PileData, sptLog ds.SPTData, FS float64) AxialCapacity {
+	NAt := func(depth float64) float64 {
+		return math.Min(math.Max(sptLog.GetN60(depth, sp.LengthUnit()), 3), 50)
+	}
+	window := ds.ConvertLength(1, "m", sp.LengthUnit())
+
+	unitShaft := func(depth float64) float64 {
+		beta := 1.0
+		if isBored(pile) {
+			if sp.IsCohesive(depth) {
+				beta = 0.8
+			} else {
+				beta = 0.5
+			}
+		}
+		return beta * (2.8*NAt(depth) + 10) * sp.AtmosphericPressure() / 100
+	}
+	unitBase := func(depth float64) float64 {
+		var Kb float64
+		switch {
+		case isBored(pile) && sp.IsCohesive(depth):
+			Kb = 80
+		case isBored(pile):
+			Kb = 165
+		case sp.IsCohesive(depth):
+			Kb = 100
+		default:
+			Kb = 325
+		}
+		N := np.Mean(sampleZone(NAt, depth-window, depth+window))
+		return Kb * N * sp.AtmosphericPressure() / 100
+	}
+
+	depths := getFieldTestDepths(sp, pile, sptLog.Depth[len(sptLog.Depth)-1])
+	return calcFieldTestCapacity(sp, pile, depths, unitShaft, unitBase, FS)
+}
+
+// CalcLCPCEquivalentConeResistance returns the equivalent cone resistance at the pile tip by the LCPC method, where
+// the cone resistances within 1.5D of the tip are clipped to 0.7-1.3 times their mean before averaging
+func CalcLCPCEquivalentConeResistance(cptLog ds.CPTData, depth, D float64) float64 {
+	qcAt := func(depth float64) float64 { return GetConeResistance(cptLog, depth) }
+	values := sampleZone(qcAt, depth-1.5*D, depth+1.5*D)
+	mean := np.Mean(values)
+	var clipped []float64
+	for _, value := range values {
+		clipped = append(clipped, math.Min(math.Max(value, 0.7*mean), 1.3*mean))
+	}
+	return np.Mean(clipped)
+}
+
+// calcLCPCUnitShaftFriction returns the unit shaft friction of the LCPC method for the given cone resistance and
+// atmospheric pressure
+func calcLCPCUnitShaftFriction(qc, pa float64, isCohesive, bored bool) float64 {
+	var alpha, limit float64
+	qcMPa := qc / (10 * pa)
+	switch {
+	case isCohesive && qcMPa < 1:
+		alpha, limit = 30, 15
+	case isCohesive && qcMPa < 5:
+		alpha, limit = 80, 35
+		if bored {
+			alpha = 40
+		}
+	case isCohesive:
+		alpha, limit = 120, 80
+		if bored {
+			alpha, limit = 60, 35
+		}
+	case qcMPa < 5:
+		alpha, limit = 150, 35
+		if bored {
+			alpha = 60
+		}
+	case qcMPa < 12:
+		alpha, limit = 200, 80
+		if bored {
+			alpha = 100
+		}
+	default:
+		alpha, limit = 200, 120
+		if bored {
+			alpha = 150
+		}
+	}
+	return math.Min(qc/alpha, limit*pa/100)
+}
+
+// CalcCPTCapacityLCPC returns the axial capacity of the pile versus depth by the LCPC method (Bustamante and
+// Gianeselli, 1982)
+func CalcCPTCapacityLCPC(sp ds.SoilProfile, pile ds.PileData, cptLog ds.CPTData, FS float64) AxialCapacity {
+	bored := isBored(pile)
+	unitShaft := func(depth float64) float64 {
+		return calcLCPCUnitShaftFriction(GetConeResistance(cptLog, depth), sp.AtmosphericPressure(), sp.IsCohesive(depth), bored)
+	}
+	unitBase := func(depth float64) float64 {
+		var kc float64
+		switch {
+		case sp.IsCohesive(depth) && bored:
+			kc = 0.375
+		case sp.IsCohesive(depth):
+			kc = 0.6
+		case bored:
+			kc = 0.15
+		default:
+			kc = 0.375
+		}
+		return kc * CalcLCPCEquivalentConeResistance(cptLog, depth, pile.Diameter)
+	}
+
+	depths := getFieldTestDepths(sp, pile, cptLog.Depth[len(cptLog.Depth)-1])
+	return calcFieldTestCapacity(sp, pile, depths, unitShaft, unitBase, FS)
+}
+
+// GetEslamiFelleniusCs returns the shaft correlation coefficient of the Eslami-Fellenius method for the soil class
+// of the layer at the given depth
+func GetEslamiFelleniusCs(sp ds.SoilProfile, depth float64) float64 {
+	soilClass := sp.SoilClass[sp.GetLayerIndex(depth)]
+	switch {
+	case strings.HasPrefix(soilClass, "O") || soilClass == "PT":
+		return 0.08
+	case strings.HasPrefix(soilClass, "C"):
+		return 0.05
+	case strings.HasPrefix(soilClass, "M"):
+		return 0.025
+	case strings.HasPrefix(soilClass, "S") && (strings.Contains(soilClass, "M") || strings.Contains(soilClass, "C")):
+		return 0.01
+	default:
+		return 0.004
+	}
+}
+
+// CalcCPTCapacityEslamiFellenius returns the axial capacity of the pile versus depth by Eslami and Fellenius (1997),
+// the method used by UniCone. The base resistance is the geometric mean of the effective cone resistance from 8D
+// above to 4D below the tip.
+func CalcCPTCapacityEslamiFellenius(sp ds.SoilProfile, pile ds.PileData, cptLog ds.CPTData, FS float64) AxialCapacity {
+	D := pile.Diameter
+	qEAt := func(depth float64) float64 { return GetEffectiveConeResistance(cptLog, depth) }
+	Ct := math.Min(1, 1/(3*ds.ConvertLength(D, sp.LengthUnit(), "m")))
+
+	unitShaft := func(depth float64) float64 {
+		return GetEslamiFelleniusCs(sp, depth) * qEAt(depth)
+	}
+	unitBase := func(depth float64) float64 {
+		return Ct * calcGeometricMean(sampleZone(qEAt, depth-8*D, depth+4*D))
+	}
+
+	depths := getFieldTestDepths(sp, pile, cptLog.Depth[len(cptLog.Depth)-1])
+	return calcFieldTestCapacity(sp, pile, depths, unitShaft, unitBase, FS)
+}
@@ -0,0 +1,88 @@
+package piles
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// GroupCapacity is a struct that contains the axial capacity of a pile group
+type GroupCapacity struct {
+	Efficiency         float64 `json:"efficiency"`
+	EfficiencyCapacity float64 `json:"efficiency_capacity"`
+	BlockCapacity      float64 `json:"block_capacity"`
+	UltimateCapacity   float64 `json:"ultimate_capacity"`
+	AllowableCapacity  float64 `json:"allowable_capacity"`
+}
+
+// GetPileCount returns the number of piles in the group
+func GetPileCount(pile ds.PileData) int {
+	return pile.Rows * pile.Columns
+}
+
+// CalcGroupDimensions returns the width and length of the block enclosing the pile group
+func CalcGroupDimensions(pile ds.PileData) (float64, float64) {
+	Bg := float64(pile.Columns-1)*pile.Spacing + pile.Diameter
+	Lg := float64(pile.Rows-1)*pile.Spacing + pile.Diameter
+	return Bg, Lg
+}
+
+// CalcConverseLabarreEfficiency returns the efficiency of the pile group by the Converse-Labarre formula
+func CalcConverseLabarreEfficiency(pile ds.PileData) float64 {
+	m := float64(pile.Rows)
+	n := float64(pile.Columns)
+	theta := math.Atan(pile.Diameter/pile.Spacing) * 180 / math.Pi
+	return 1 - theta*((n-1)*m+(m-1)*n)/(90*m*n)
+}
+
+// CalcBlockCapacity returns the capacity of the pile group failing as a block in clay, with the shaft resistance of
+// the block perimeter mobilizing the full undrained shear strength and the base factor of Skempton (1951)
+func CalcBlockCapacity(sp ds.SoilProfile, pile ds.PileData) float64 {
+	Bg, Lg := CalcGroupDimensions(pile)
+	centers, thicknesses := settlement.GetSublayers(sp, 0, pile.Length, settlement.SublayerThickness)
+
+	var shaftResistance float64
+	for i, center := range centers {
+		shaftResistance += sp.Cu[sp.GetLayerIndex(center)] * thicknesses[i]
+	}
+	shaftResistance *= 2 * (Bg + Lg)
+
+	Nc := math.Min(5*(1+0.2*pile.Length/Bg)*(1+0.2*Bg/Lg), 9)
+	CuBase := sp.Cu[sp.GetLayerIndex(pile.Length)]
+	return shaftResistance + Nc*CuBase*Bg*Lg
+}
+
+// CalcGroupCapacity returns the capacity of the pile group as the lesser of the efficiency reduced sum of the
+// single pile capacities and the block capacity
+func CalcGroupCapacity(sp ds.SoilProfile, pile ds.PileData, singlePileCapacity, FS float64) GroupCapacity {
+	efficiency := CalcConverseLabarreEfficiency(pile)
+	efficiencyCapacity := efficiency * float64(GetPileCount(pile)) * singlePileCapacity
+	blockCapacity := CalcBlockCapacity(sp, pile)
+	ultimateCapacity := math.Min(efficiencyCapacity, blockCapacity)
+
+	return GroupCapacity{
+		Efficiency:         efficiency,
+		EfficiencyCapacity: efficiencyCapacity,
+		BlockCapacity:      blockCapacity,
+		UltimateCapacity:   ultimateCapacity,
+		AllowableCapacity:  ultimateCapacity / FS,
+	}
+}
+
+// GetEquivalentRaft returns the equivalent raft of the pile group, which carries the total load of the pile cap at
+// two thirds of the pile length
+func GetEquivalentRaft(bd ds.BuildingData) ds.BuildingData {
+	Bg, Lg := CalcGroupDimensions(bd.Pile)
+	raft := bd
+	raft.Df = 2 * bd.Pile.Length / 3
+	raft.B = Bg
+	raft.L = Lg
+	raft.Q = bd.Q * bd.B * bd.L / (Bg * Lg)
+	return raft
+}
+
+// CalcGroupSettlement returns the consolidation settlement of the pile group by the equivalent raft method
+func CalcGroupSettlement(sp ds.SoilProfile, bd ds.BuildingData) float64 {
+	return settlement.CalcConsolidationSettlement(sp, GetEquivalentRaft(bd))
+}
@@ -0,0 +1,314 @@
+package piles
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// LateralSegments is the number of segments of the finite difference model of a laterally loaded pile
+const LateralSegments = 100
+
+// stiffClayCu is the undrained shear strength above which clays are modelled as stiff clay in kPa
+const stiffClayCu = 96.0
+
+// lateralTolerance is the deflection tolerance of the secant stiffness iterations
+const lateralTolerance = 1e-7
+
+// lateralMaxIterations is the maximum number of secant stiffness iterations
+const lateralMaxIterations = 200
+
+// PYCurve is a struct that contains the p-y curve of the soil at a depth
+type PYCurve struct {
+	Depth float64   `json:"depth"`
+	Model string    `json:"model"`
+	Y     []float64 `json:"y"`
+	P     []float64 `json:"p"`
+}
+
+// LateralResponse is a struct that contains the response of a laterally loaded pile along its length
+type LateralResponse struct {
+	Depth        []float64 `json:"depth"`
+	Deflection   []float64 `json:"deflection"`
+	Moment       []float64 `json:"moment"`
+	Shear        []float64 `json:"shear"`
+	SoilReaction []float64 `json:"soil_reaction"`
+	Converged    bool      `json:"converged"`
+}
+
+// GetPYModel returns the p-y model of the layer at the given depth, which is matlock for soft clays, reese for
+// stiff clays and api for sands
+func GetPYModel(sp ds.SoilProfile, depth float64) string {
+	if !sp.IsCohesive(depth) {
+		return "api"
+	}
+	if sp.Cu[sp.GetLayerIndex(depth)] <= stiffClayCu*sp.AtmosphericPressure()/100 {
+		return "matlock"
+	}
+	return "reese"
+}
+
+// calcEpsilon50 returns the strain at half the maximum deviator stress of a clay for the given undrained shear
+// strength and atmospheric pressure (Reese and Van Impe, 2001)
+func calcEpsilon50(Cu, pa float64) float64 {
+	Cu = Cu * 100 / pa
+	switch {
+	case Cu < 50:
+		return 0.02
+	case Cu < 100:
+		return 0.01
+	case Cu < 200:
+		return 0.007
+	default:
+		return 0.005
+	}
+}
+
+// calcMatlockP returns the soil reaction of soft clay at the given depth and deflection (Matlock, 1970)
+func calcMatlockP(sp ds.SoilProfile, D, depth, y float64) float64 {
+	Cu := sp.Cu[sp.GetLayerIndex(depth)]
+	sigma := sp.CalcEffectiveStress(depth)
+	pu := math.Min((3+sigma/Cu+0.5*depth/D)*Cu*D, 9*Cu*D)
+	y50 := 2.5 * calcEpsilon50(Cu, sp.AtmosphericPressure()) * D
+	if y >= 8*y50 {
+		return pu
+	}
+	return 0.5 * pu * math.Cbrt(y/y50)
+}
+
+// calcReeseP returns the soil reaction of stiff clay below free water at the given depth and deflection for static
+// loading (Reese et al., 1975)
+func calcReeseP(sp ds.SoilProfile, D, depth, y float64) float64 {
+	Cu := sp.Cu[sp.GetLayerIndex(depth)]
+	sigma := sp.CalcEffectiveStress(depth)
+	pc := math.Min(2*Cu*D+sigma*D+2.83*Cu*depth, 11*Cu*D)
+	y50 := calcEpsilon50(Cu, sp.AtmosphericPressure()) * D
+	As := 0.2 + 0.4*math.Tanh(0.62*depth/D)
+
+	var ks float64
+	switch CuKPa := Cu * 100 / sp.AtmosphericPressure(); {
+	case CuKPa < 100:
+		ks = 135000
+	case CuKPa < 200:
+		ks = 270000
+	default:
+		ks = 540000
+	}
+	ks *= sp.AtmosphericPressure() / 100
+
+	var p float64
+	switch {
+	case y <= As*y50:
+		p = 0.5 * pc * math.Sqrt(y/y50)
+	case y <= 6*As*y50:
+		p = 0.5*pc*math.Sqrt(y/y50) - 0.055*pc*math.Pow((y-As*y50)/(As*y50), 1.25)
+	case y <= 18*As*y50:
+		p = 0.5*pc*math.Sqrt(6*As) - 0.411*pc - 0.0625*pc*(y-6*As*y50)/y50
+	default:
+		p = pc * (1.225*math.Sqrt(As) - 0.75*As - 0.411)
+	}
+	return math.Min(ks*depth*y, p)
+}
+
+// calcAPISandModulus returns the initial modulus of subgrade reaction of sand for the given friction angle in
+// degrees and atmospheric pressure (API, 2000)
+func calcAPISandModulus(phi, pa float64, submerged bool) float64 {
+	phis := []float64{29, 33, 38}
+	k := []float64{6800, 24400, 61000}
+	if submerged {
+		k = []float64{5400, 16300, 34000}
+	}
+	return np.Interp([]float64{phi}, phis, k)[0] * pa / 100
+}
+
+// CalcSandUltimateResistance returns the ultimate lateral resistance of sand per unit length as the lesser of the
+// wedge and flow around resistances (Reese et al., 1974)
+func CalcSandUltimateResistance(sp ds.SoilProfile, D, depth float64) float64 {
+	if depth <= 0 {
+		return 0
+	}
+	phi := sp.Phi[sp.GetLayerIndex(depth)] * math.Pi / 180
+	sigma := sp.CalcEffectiveStress(depth)
+	alpha := phi / 2
+	beta := math.Pi/4 + phi/2
+	K0 := 0.4
+	Ka := math.Pow(math.Tan(math.Pi/4-phi/2), 2)
+	tanBeta := math.Tan(beta)
+
+	pst := sigma * (K0*depth*math.Tan(phi)*math.Sin(beta)/(math.Tan(beta-phi)*math.Cos(alpha)) +
+		tanBeta/math.Tan(beta-phi)*(D+depth*tanBeta*math.Tan(alpha)) +
+		K0*depth*tanBeta*(math.Tan(phi)*math.Sin(beta)-math.Tan(alpha)) -
+		Ka*D)
+	psd := Ka*D*sigma*(math.Pow(tanBeta, 8)-1) + K0*D*sigma*math.Tan(phi)*math.Pow(tanBeta, 4)
+	return math.Min(pst, psd)
+}
+
+// calcAPISandP returns the soil reaction of sand at the given depth and deflection for static loading (API, 2000)
+func calcAPISandP(sp ds.SoilProfile, D, depth, y float64) float64 {
+	pu := CalcSandUltimateResistance(sp, D, depth)
+	if pu <= 0 {
+		return 0
+	}
+	A := math.Max(3-0.8*depth/D, 0.9)
+	k := calcAPISandModulus(sp.Phi[sp.GetLayerIndex(depth)], sp.AtmosphericPressure(), sp.CalcPorePressure(depth) > 0)
+	return A * pu * math.Tanh(k*depth*y/(A*pu))
+}
+
+// CalcSoilReaction returns the soil reaction per unit length of the pile at the given depth and deflection
+func CalcSoilReaction(sp ds.SoilProfile, pile ds.PileData, depth, y float64) float64 {
+	yAbs := math.Abs(y)
+	var p float64
+	switch GetPYModel(sp, depth) {
+	case "matlock":
+		p = calcMatlockP(sp, pile.Diameter, depth, yAbs)
+	case "reese":
+		p = calcReeseP(sp, pile.Diameter, depth, yAbs)
+	default:
+		p = calcAPISandP(sp, pile.Diameter, depth, yAbs)
+	}
+	return math.Copysign(p, y)
+}
+
+// CalcPYCurve returns the p-y curve of the soil at the given depth up to the given deflection
+func CalcPYCurve(sp ds.SoilProfile, pile ds.PileData, depth, maxDeflection float64, nPoints int) PYCurve {
+	curve := PYCurve{Depth: depth, Model: GetPYModel(sp, depth)}
+	for i := 0; i <= nPoints; i++ {
+		y := maxDeflection * float64(i) / float64(nPoints)
+		curve.Y = append(curve.Y, y)
+		curve.P = append(curve.P, CalcSoilReaction(sp, pile, depth, y))
+	}
+	return curve
+}
+
+// CalcPYCurves returns the p-y curves at the center of each layer crossed by the pile
+func CalcPYCurves(sp ds.SoilProfile, pile ds.PileData, maxDeflection float64, nPoints int) []PYCurve {
+	var curves []PYCurve
+	for _, center := range sp.GetLayerCenters() {
+		if center < pile.Length {
+			curves = append(curves, CalcPYCurve(sp, pile, center, maxDeflection, nPoints))
+		}
+	}
+	return curves
+}
+
+// solveLinearSystem solves the linear system Ax=b by gaussian elimination with partial pivoting
+func solveLinearSystem(A [][]float64, b []float64) []float64 {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(A[row][col]) > math.Abs(A[pivot][col]) {
+				pivot = row
+			}
+		}
+		A[col], A[pivot] = A[pivot], A[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := A[row][col] / A[col][col]
+			if factor == 0 {
+				continue
+			}
+			for k := col; k < n; k++ {
+				A[row][k] -= factor * A[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= A[row][k] * x[k]
+		}
+		x[row] = sum / A[row][row]
+	}
+	return x
+}
+
+// solveBeamOnSprings returns the deflections of a free headed pile on linear springs of given stiffness per unit
+// length under head shear H and head moment M, including two imaginary nodes at each end
+func solveBeamOnSprings(EI, h float64, k []float64, H, M float64) []float64 {
+	nNodes := len(k)
+	size := nNodes + 4
+	A := np.Zeros(size, size)
+	b := make([]float64, size)
+
+	// node i of the pile is the unknown i+2
+	for i := 0; i < nNodes; i++ {
+		row := i + 2
+		c := EI / math.Pow(h, 4)
+		A[row][row-2] = c
+		A[row][row-1] = -4 * c
+		A[row][row] = 6*c + k[i]
+		A[row][row+1] = -4 * c
+		A[row][row+2] = c
+	}
+
+	// moment and shear at the head
+	A[0][1], A[0][2], A[0][3] = EI/math.Pow(h, 2), -2*EI/math.Pow(h, 2), EI/math.Pow(h, 2)
+	b[0] = M
+	A[1][0], A[1][1], A[1][3], A[1][4] = -EI/(2*math.Pow(h, 3)), EI/math.Pow(h, 3), -EI/math.Pow(h, 3), EI/(2*math.Pow(h, 3))
+	b[1] = H
+
+	// zero moment and shear at the tip
+	last := nNodes + 1
+	A[size-2][last-1], A[size-2][last], A[size-2][last+1] = 1, -2, 1
+	A[size-1][last-2], A[size-1][last-1], A[size-1][last+1], A[size-1][last+2] = -1, 2, -2, 1
+
+	return solveLinearSystem(A, b)
+}
+
+// CalcLateralResponse returns the deflection, moment, shear and soil reaction along a free headed pile under the
+// given head shear and moment by solving the beam on nonlinear p-y springs with finite differences
+func CalcLateralResponse(sp ds.SoilProfile, pile ds.PileData, H, M float64) LateralResponse {
+	h := pile.Length / LateralSegments
+	nNodes := LateralSegments + 1
+	EI := pile.EI
+
+	var depths []float64
+	for i := 0; i < nNodes; i++ {
+		depths = append(depths, float64(i)*h)
+	}
+
+	// initial secant stiffness from a small deflection
+	k := make([]float64, nNodes)
+	for i, depth := range depths {
+		k[i] = CalcSoilReaction(sp, pile, depth, 1e-5) / 1e-5
+	}
+
+	var y []float64
+	var converged bool
+	for iteration := 0; iteration < lateralMaxIterations; iteration++ {
+		solution := solveBeamOnSprings(EI, h, k, H, M)
+		var maxChange float64
+		for i := range depths {
+			if y != nil {
+				maxChange = math.Max(maxChange, math.Abs(solution[i+2]-y[i+2]))
+			}
+		}
+		y = solution
+		for i, depth := range depths {
+			deflection := y[i+2]
+			if math.Abs(deflection) > 1e-12 {
+				k[i] = CalcSoilReaction(sp, pile, depth, deflection) / deflection
+			}
+		}
+		if iteration > 0 && maxChange < lateralTolerance {
+			converged = true
+			break
+		}
+	}
+
+	response := LateralResponse{Depth: depths, Converged: converged}
+	for i, depth := range depths {
+		j := i + 2
+		response.Deflection = append(response.Deflection, y[j])
+		response.Moment = append(response.Moment, EI*(y[j-1]-2*y[j]+y[j+1])/math.Pow(h, 2))
+		response.Shear = append(response.Shear, EI*(y[j+2]-2*y[j+1]+2*y[j-1]-y[j-2])/(2*math.Pow(h, 3)))
+		response.SoilReaction = append(response.SoilReaction, CalcSoilReaction(sp, pile, depth, y[j]))
+	}
+	return response
+}
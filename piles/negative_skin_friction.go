@@ -0,0 +1,103 @@
+package piles
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// NeutralPlaneSegments is the number of segments used to evaluate the load and resistance curves of a pile
+const NeutralPlaneSegments = 200
+
+// NeutralPlaneAnalysis is a struct that contains the results of the unified design method for a pile subjected to
+// negative skin friction
+type NeutralPlaneAnalysis struct {
+	Depth             []float64 `json:"depth"`
+	LoadCurve         []float64 `json:"load_curve"`
+	ResistanceCurve   []float64 `json:"resistance_curve"`
+	SoilSettlement    []float64 `json:"soil_settlement"`
+	NeutralPlaneDepth float64   `json:"neutral_plane_depth"`
+	DragForce         float64   `json:"drag_force"`
+	MaxLoad           float64   `json:"max_load"`
+	ElasticShortening float64   `json:"elastic_shortening"`
+	PileSettlement    float64   `json:"pile_settlement"`
+}
+
+// CalcUnitShaftFriction returns the unit shaft friction at the given depth by the alpha method in cohesive layers
+// and the beta method in cohesionless layers
+func CalcUnitShaftFriction(sp ds.SoilProfile, pile ds.PileData, depth float64) float64 {
+	if sp.IsCohesive(depth) {
+		return CalcUnitShaftFrictionAlpha(sp, pile, depth)
+	}
+	return CalcUnitShaftFrictionBeta(sp, pile, depth)
+}
+
+// calcElasticShortening returns the elastic shortening of the pile from the head to the given depth under the axial
+// loads at the given depths
+func calcElasticShortening(pile ds.PileData, depths, loads []float64, depth float64) float64 {
+	if pile.EA <= 0 {
+		return 0
+	}
+	var area float64
+	for i := 1; i < len(depths) && depths[i-1] < depth; i++ {
+		bottom := math.Min(depths[i], depth)
+		bottomLoad := np.Interp([]float64{bottom}, depths, loads)[0]
+		area += (loads[i-1] + bottomLoad) / 2 * (bottom - depths[i-1])
+	}
+	return area / pile.EA
+}
+
+// CalcNeutralPlane returns the neutral plane of the pile by the unified method (Fellenius, 2004). The load curve
+// starts from the dead load at the head and accumulates negative skin friction downwards, the resistance curve
+// starts from the mobilized toe resistance and accumulates positive shaft resistance upwards and the neutral plane
+// is at their intersection. The settlement of the ground is the consolidation settlement under the given fill
+// pressure, and the pile head settles by the settlement of the ground at the neutral plane plus the elastic
+// shortening of the pile above the neutral plane under the load curve. A pile without axial rigidity EA is taken as
+// rigid.
+func CalcNeutralPlane(sp ds.SoilProfile, pile ds.PileData, deadLoad, fillPressure, toeMobilization float64) NeutralPlaneAnalysis {
+	depths := np.LinSpace(0, pile.Length, NeutralPlaneSegments+1)
+	perimeter := CalcPerimeter(pile)
+	h := pile.Length / NeutralPlaneSegments
+
+	// cumulative shaft resistance from the head
+	shaft := []float64{0}
+	for i := 1; i < len(depths); i++ {
+		qs := CalcUnitShaftFriction(sp, pile, (depths[i-1]+depths[i])/2)
+		shaft = append(shaft, shaft[i-1]+qs*perimeter*h)
+	}
+	totalShaft := shaft[len(shaft)-1]
+	toeResistance := toeMobilization * CalcBaseResistance(sp, pile, pile.Length, "meyerhof")
+
+	analysis := NeutralPlaneAnalysis{
+		Depth:          depths,
+		SoilSettlement: settlement.CalcSettlementProfile(sp, depths, fillPressure),
+	}
+	for i := range depths {
+		analysis.LoadCurve = append(analysis.LoadCurve, deadLoad+shaft[i])
+		analysis.ResistanceCurve = append(analysis.ResistanceCurve, toeResistance+totalShaft-shaft[i])
+	}
+
+	// the load curve increases and the resistance curve decreases with depth
+	neutralPlane := pile.Length
+	for i := range depths {
+		diff := analysis.LoadCurve[i] - analysis.ResistanceCurve[i]
+		if diff >= 0 {
+			if i == 0 {
+				neutralPlane = 0
+			} else {
+				prevDiff := analysis.LoadCurve[i-1] - analysis.ResistanceCurve[i-1]
+				neutralPlane = depths[i-1] + h*prevDiff/(prevDiff-diff)
+			}
+			break
+		}
+	}
+
+	analysis.NeutralPlaneDepth = neutralPlane
+	analysis.DragForce = np.Interp([]float64{neutralPlane}, depths, shaft)[0]
+	analysis.MaxLoad = deadLoad + analysis.DragForce
+	analysis.ElasticShortening = calcElasticShortening(pile, depths, analysis.LoadCurve, neutralPlane)
+	analysis.PileSettlement = np.Interp([]float64{neutralPlane}, depths, analysis.SoilSettlement)[0] + analysis.ElasticShortening
+	return analysis
+}
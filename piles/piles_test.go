@@ -0,0 +1,310 @@
+package piles
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var soilProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL", "SP"},
+	Thickness:           []float64{5, 10},
+	DryUnitWeight:       []float64{18, 19},
+	SaturatedUnitWeight: []float64{19, 20},
+	Cu:                  []float64{50, 0},
+	Phi:                 []float64{0, 32},
+	ElasticModulus:      []float64{15000, 40000},
+	PoissonRatio:        []float64{0.4, 0.3},
+	Gwt:                 20,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var drivenPile = ds.PileData{
+	Type:     "driven",
+	Material: "concrete",
+	Diameter: 0.5,
+	Length:   12,
+}
+
+func TestCapacityFactors(t *testing.T) {
+	expected := []float64{0.487, 1.2694, 0.227, 82.0889}
+	output := np.Round([]float64{
+		CalcAlpha(drivenPile, 50, 45),
+		CalcKdelta(32, 0.196),
+		CalcLambda(12),
+		CalcMeyerhofNq(32),
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestUnitResistances(t *testing.T) {
+	expected := []float64{24.35, 49.66, 80.64, 2564.74, 9422.17, 502.22}
+	output := np.Round([]float64{
+		CalcUnitShaftFrictionAlpha(soilProfile, drivenPile, 2.5),
+		CalcUnitShaftFrictionBeta(soilProfile, drivenPile, 8),
+		CalcUnitShaftFrictionNordlund(soilProfile, drivenPile, 8),
+		CalcUnitBaseResistanceMeyerhof(soilProfile, 12),
+		CalcUnitBaseResistanceVesic(soilProfile, 12),
+		CalcUnitBaseResistanceVesic(soilProfile, 4),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcAxialCapacity(t *testing.T) {
+	capacity := CalcAxialCapacity(soilProfile, drivenPile, "alpha", "beta", "meyerhof", 2.5)
+	n := len(capacity.Depth)
+	expected := []float64{24, 12, 1275.37, 510.15}
+	output := []float64{
+		float64(n),
+		capacity.Depth[n-1],
+		np.RoundFloat(capacity.UltimateCapacity[n-1], 2),
+		np.RoundFloat(capacity.AllowableCapacity[n-1], 2),
+	}
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	expectedLambda := 1202.53
+	outputLambda := np.RoundFloat(CalcShaftResistance(soilProfile, drivenPile, 12, "lambda", "nordlund"), 2)
+	if outputLambda != expectedLambda {
+		t.Errorf("Expected %v, got %v", expectedLambda, outputLambda)
+	}
+}
+
+var TestSPTData = ds.SPTData{
+	Ce:         1.2,
+	Cb:         1,
+	Cs:         1,
+	Correction: true,
+	Depth:      []float64{2, 4, 6, 8, 10, 12},
+	N:          []int{8, 10, 20, 25, 30, 35},
+}
+
+var TestCPTData = ds.CPTData{
+	Depth:          []float64{0, 5, 5.5, 15},
+	ConeResistance: []float64{1000, 1500, 8000, 15000},
+	PorePressure:   []float64{0, 50, 50, 150},
+}
+
+func TestGetCapacityDepths(t *testing.T) {
+	// the depth step is converted to the length unit of the soil profile
+	sp := ds.SoilProfile{Thickness: []float64{4}, PressureUnit: "psf"}
+	expected := []float64{1.6404, 3.2808, 4}
+	output := np.Round(GetCapacityDepths(sp, ds.PileData{}), 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestFieldTestInterpretation(t *testing.T) {
+	expected := []float64{9105.26, 9039.47, 4526.97}
+	output := np.Round([]float64{
+		GetConeResistance(TestCPTData, 7),
+		GetEffectiveConeResistance(TestCPTData, 7),
+		CalcLCPCEquivalentConeResistance(TestCPTData, 5.2, 0.5),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestFieldTestCapacity(t *testing.T) {
+	pile := drivenPile
+	pile.Length = 10
+	expectedShaft := []float64{657.85, 1078.07, 484.76, 766.81}
+	expectedBase := []float64{2645.67, 2479.61, 833.19, 1362.44}
+
+	capacities := []AxialCapacity{
+		CalcSPTCapacityMeyerhof(soilProfile, pile, TestSPTData, 2.5),
+		CalcSPTCapacityDecourt(soilProfile, pile, TestSPTData, 2.5),
+		CalcCPTCapacityLCPC(soilProfile, pile, TestCPTData, 2.5),
+		CalcCPTCapacityEslamiFellenius(soilProfile, pile, TestCPTData, 2.5),
+	}
+	var outputShaft, outputBase []float64
+	for _, capacity := range capacities {
+		n := len(capacity.Depth)
+		outputShaft = append(outputShaft, capacity.ShaftResistance[n-1])
+		outputBase = append(outputBase, capacity.BaseResistance[n-1])
+	}
+
+	if reflect.DeepEqual(np.Round(outputShaft, 2), expectedShaft) == false {
+		t.Errorf("Expected %v, got %v", expectedShaft, outputShaft)
+	}
+	if reflect.DeepEqual(np.Round(outputBase, 2), expectedBase) == false {
+		t.Errorf("Expected %v, got %v", expectedBase, outputBase)
+	}
+}
+
+var clayProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL"},
+	Thickness:           []float64{20},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{19},
+	Cu:                  []float64{60},
+	Cc:                  []float64{0.25},
+	VoidRatio:           []float64{0.8},
+	Gwt:                 20,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var pileGroup = ds.PileData{
+	Type:     "driven",
+	Diameter: 0.5,
+	Length:   12,
+	Rows:     3,
+	Columns:  3,
+	Spacing:  1.5,
+}
+
+func TestCalcGroupCapacity(t *testing.T) {
+	expected := []float64{0.7269, 3925.2041, 16695, 1308.4014}
+	capacity := CalcGroupCapacity(clayProfile, pileGroup, 600, 3)
+	output := np.Round([]float64{
+		capacity.Efficiency,
+		capacity.EfficiencyCapacity,
+		capacity.BlockCapacity,
+		capacity.AllowableCapacity,
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcGroupSettlement(t *testing.T) {
+	bd := ds.BuildingData{B: 4, L: 4, Q: 150, Pile: pileGroup}
+	raft := GetEquivalentRaft(bd)
+
+	expectedRaft := []float64{8, 3.5, 3.5, 195.92}
+	outputRaft := np.Round([]float64{raft.Df, raft.B, raft.L, raft.Q}, 2)
+	if reflect.DeepEqual(outputRaft, expectedRaft) == false {
+		t.Errorf("Expected %v, got %v", expectedRaft, outputRaft)
+	}
+
+	expected := 0.166
+	output := np.RoundFloat(CalcGroupSettlement(clayProfile, bd), 3)
+	if output != expected {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+var layeredProfile = ds.SoilProfile{
+	SoilClass:           []string{"CL", "SP", "CH"},
+	Thickness:           []float64{4, 6, 10},
+	DryUnitWeight:       []float64{18, 19, 19},
+	SaturatedUnitWeight: []float64{19, 20, 20},
+	Cu:                  []float64{40, 0, 150},
+	Phi:                 []float64{0, 34, 0},
+	Gwt:                 3,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var lateralPile = ds.PileData{
+	Diameter: 0.8,
+	Length:   18,
+	EI:       1e6,
+}
+
+func TestSolveBeamOnSprings(t *testing.T) {
+	// Hetenyi (1946) solution for a long pile on linear springs
+	EI, k, L := 1e5, 10000.0, 20.0
+	nNodes := 201
+	springs := make([]float64, nNodes)
+	for i := range springs {
+		springs[i] = k
+	}
+	beta := math.Pow(k/(4*EI), 0.25)
+
+	expected := []float64{1, 1}
+	output := np.Round([]float64{
+		solveBeamOnSprings(EI, L/float64(nNodes-1), springs, 100, 0)[2] / (2 * 100 * beta / k),
+		solveBeamOnSprings(EI, L/float64(nNodes-1), springs, 0, 100)[2] / (2 * 100 * math.Pow(beta, 2) / k),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcPYCurves(t *testing.T) {
+	expectedModels := []string{"matlock", "api", "reese"}
+	expectedP := []float64{82.4, 1818.52, 417.46}
+	curves := CalcPYCurves(layeredProfile, lateralPile, 0.05, 5)
+
+	var outputModels []string
+	var outputP []float64
+	for _, curve := range curves {
+		outputModels = append(outputModels, curve.Model)
+		outputP = append(outputP, curve.P[4])
+	}
+	if reflect.DeepEqual(outputModels, expectedModels) == false {
+		t.Errorf("Expected %v, got %v", expectedModels, outputModels)
+	}
+	if reflect.DeepEqual(np.Round(outputP, 2), expectedP) == false {
+		t.Errorf("Expected %v, got %v", expectedP, outputP)
+	}
+}
+
+func TestCalcLateralResponse(t *testing.T) {
+	response := CalcLateralResponse(layeredProfile, lateralPile, 200, 100)
+	maxMoment, _ := np.Max(np.Abs(response.Moment))
+
+	expected := []float64{1, 0.0123, 100, 200, 579.6}
+	var converged float64
+	if response.Converged {
+		converged = 1
+	}
+	output := []float64{
+		converged,
+		np.RoundFloat(response.Deflection[0], 4),
+		np.RoundFloat(response.Moment[0], 1),
+		np.RoundFloat(response.Shear[0], 1),
+		np.RoundFloat(maxMoment, 1),
+	}
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcNeutralPlane(t *testing.T) {
+	sp := ds.SoilProfile{
+		SoilClass:           []string{"SP", "CL", "SP"},
+		Thickness:           []float64{2, 10, 10},
+		DryUnitWeight:       []float64{19, 17, 19},
+		SaturatedUnitWeight: []float64{20, 18, 20},
+		Cu:                  []float64{0, 40, 0},
+		Phi:                 []float64{30, 0, 35},
+		Cc:                  []float64{0, 0.4, 0},
+		VoidRatio:           []float64{0.6, 1.1, 0.6},
+		Gwt:                 1,
+		CheckGwt:            true,
+		DensityUnit:         "kN/m3",
+		PressureUnit:        "kPa",
+	}
+	pile := ds.PileData{Type: "driven", Diameter: 0.4, Length: 14, EA: 3e6}
+
+	analysis := CalcNeutralPlane(sp, pile, 200, 40, 0.5)
+	expected := []float64{10.473, 280.5635, 480.5635, 0.0011, 0.0412, 0.4061}
+	output := np.Round([]float64{
+		analysis.NeutralPlaneDepth,
+		analysis.DragForce,
+		analysis.MaxLoad,
+		analysis.ElasticShortening,
+		analysis.PileSettlement,
+		analysis.SoilSettlement[0],
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
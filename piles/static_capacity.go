@@ -0,0 +1,247 @@
+package piles
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// DepthStep is the interval in m of the depths at which the capacity curves are evaluated
+const DepthStep = 0.5
+
+// AxialCapacity is a struct that contains the axial capacity of a pile for a range of pile lengths
+type AxialCapacity struct {
+	Depth             []float64 `json:"depth"`
+	ShaftResistance   []float64 `json:"shaft_resistance"`
+	BaseResistance    []float64 `json:"base_resistance"`
+	UltimateCapacity  []float64 `json:"ultimate_capacity"`
+	AllowableCapacity []float64 `json:"allowable_capacity"`
+}
+
+// CalcPerimeter returns the perimeter of the pile shaft
+func CalcPerimeter(pile ds.PileData) float64 {
+	if pile.Shape == "square" {
+		return 4 * pile.Diameter
+	}
+	return math.Pi * pile.Diameter
+}
+
+// CalcBaseArea returns the cross-sectional area of the pile base
+func CalcBaseArea(pile ds.PileData) float64 {
+	if pile.Shape == "square" {
+		return math.Pow(pile.Diameter, 2)
+	}
+	return math.Pi * math.Pow(pile.Diameter, 2) / 4
+}
+
+// isBored returns true if the pile is installed by boring
+func isBored(pile ds.PileData) bool {
+	return pile.Type == "bored"
+}
+
+// getFrictionAngleRatio returns the ratio of the pile-soil interface friction angle to the soil friction angle
+// (Kulhawy, 1991)
+func getFrictionAngleRatio(pile ds.PileData) float64 {
+	if isBored(pile) {
+		return 1
+	}
+	if pile.Material == "steel" {
+		return 0.7
+	}
+	return 0.8
+}
+
+// CalcAlpha returns the adhesion factor of the pile shaft for the given undrained shear strength and effective
+// stress. Driven piles use the API (1987) factor, bored piles use 0.55 (O'Neill and Reese, 1999).
+func CalcAlpha(pile ds.PileData, Cu, effectiveStress float64) float64 {
+	if isBored(pile) {
+		return 0.55
+	}
+	psi := Cu / effectiveStress
+	var alpha float64
+	if psi <= 1 {
+		alpha = 0.5 * math.Pow(psi, -0.5)
+	} else {
+		alpha = 0.5 * math.Pow(psi, -0.25)
+	}
+	return math.Min(alpha, 1)
+}
+
+// CalcUnitShaftFrictionAlpha returns the unit shaft friction at the given depth by the alpha method
+func CalcUnitShaftFrictionAlpha(sp ds.SoilProfile, pile ds.PileData, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	Cu := sp.Cu[layerIndex]
+	return CalcAlpha(pile, Cu, sp.CalcEffectiveStress(depth)) * Cu
+}
+
+// CalcUnitShaftFrictionBeta returns the unit shaft friction at the given depth by the beta method, where the
+// lateral earth pressure coefficient is 1.5K0 for driven and K0 for bored piles (Kulhawy, 1991)
+func CalcUnitShaftFrictionBeta(sp ds.SoilProfile, pile ds.PileData, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	phi := sp.Phi[layerIndex] * math.Pi / 180
+	K := 1 - math.Sin(phi)
+	if !isBored(pile) {
+		K *= 1.5
+	}
+	delta := getFrictionAngleRatio(pile) * phi
+	return K * math.Tan(delta) * sp.CalcEffectiveStress(depth)
+}
+
+// CalcKdelta returns the coefficient of lateral earth pressure of the Nordlund (1963) method for a uniform pile with
+// the given friction angle in degrees and displaced volume per unit length in m3/m
+func CalcKdelta(phi, displacedVolume float64) float64 {
+	phis := []float64{25, 30, 35, 40}
+	volumes := []float64{0.0093, 0.093, 0.93}
+	KdeltaTable := [][]float64{
+		{0.70, 0.84, 0.99, 1.18},
+		{0.85, 1.07, 1.35, 1.73},
+		{1.00, 1.32, 1.65, 2.15},
+	}
+	var Kdeltas []float64
+	for _, row := range KdeltaTable {
+		Kdeltas = append(Kdeltas, np.Interp([]float64{phi}, phis, row)[0])
+	}
+	var logVolumes []float64
+	for _, volume := range volumes {
+		logVolumes = append(logVolumes, math.Log10(volume))
+	}
+	return np.Interp([]float64{math.Log10(displacedVolume)}, logVolumes, Kdeltas)[0]
+}
+
+// CalcUnitShaftFrictionNordlund returns the unit shaft friction at the given depth by the Nordlund (1963) method
+// for a uniform pile. The correction factor for the interface friction angle is taken as 1.
+func CalcUnitShaftFrictionNordlund(sp ds.SoilProfile, pile ds.PileData, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	phi := sp.Phi[layerIndex]
+	displacedVolume := CalcBaseArea(pile) * math.Pow(ds.ConvertLength(1, sp.LengthUnit(), "m"), 2)
+	Kdelta := CalcKdelta(phi, displacedVolume)
+	delta := getFrictionAngleRatio(pile) * phi * math.Pi / 180
+	return Kdelta * math.Sin(delta) * sp.CalcEffectiveStress(depth)
+}
+
+// CalcLambda returns the frictional capacity coefficient of the lambda method for the given pile length in m
+// (Vijayvergiya and Focht, 1972)
+func CalcLambda(length float64) float64 {
+	lengths := []float64{0, 5, 10, 15, 20, 25, 30, 35, 40, 50, 60, 70}
+	lambdas := []float64{0.5, 0.336, 0.245, 0.2, 0.173, 0.15, 0.136, 0.132, 0.127, 0.118, 0.113, 0.11}
+	return np.Interp([]float64{length}, lengths, lambdas)[0]
+}
+
+// CalcShaftResistance returns the shaft resistance of a pile embedded to the given depth. Cohesive layers use the
+// alpha or lambda method and cohesionless layers use the beta or nordlund method.
+func CalcShaftResistance(sp ds.SoilProfile, pile ds.PileData, depth float64, clayMethod, sandMethod string) float64 {
+	perimeter := CalcPerimeter(pile)
+	centers, thicknesses := settlement.GetSublayers(sp, 0, depth, settlement.SublayerThickness)
+
+	var Qs, clayLength, clayStress, clayCu float64
+	for i, center := range centers {
+		if sp.IsCohesive(center) {
+			if clayMethod == "lambda" {
+				clayLength += thicknesses[i]
+				clayStress += sp.CalcEffectiveStress(center) * thicknesses[i]
+				clayCu += sp.Cu[sp.GetLayerIndex(center)] * thicknesses[i]
+				continue
+			}
+			Qs += CalcUnitShaftFrictionAlpha(sp, pile, center) * perimeter * thicknesses[i]
+		} else if sandMethod == "nordlund" {
+			Qs += CalcUnitShaftFrictionNordlund(sp, pile, center) * perimeter * thicknesses[i]
+		} else {
+			Qs += CalcUnitShaftFrictionBeta(sp, pile, center) * perimeter * thicknesses[i]
+		}
+	}
+
+	if clayLength > 0 {
+		meanStress := clayStress / clayLength
+		meanCu := clayCu / clayLength
+		Qs += CalcLambda(ds.ConvertLength(depth, sp.LengthUnit(), "m")) * (meanStress + 2*meanCu) * perimeter * clayLength
+	}
+	return Qs
+}
+
+// CalcMeyerhofNq returns the bearing capacity factor of Meyerhof (1976) for deep foundations for the given friction
+// angle in degrees
+func CalcMeyerhofNq(phi float64) float64 {
+	phis := []float64{20, 25, 30, 35, 40, 45}
+	Nqs := []float64{12.4, 26, 56.7, 143, 346, 930}
+	var logNqs []float64
+	for _, Nq := range Nqs {
+		logNqs = append(logNqs, math.Log(Nq))
+	}
+	return math.Exp(np.Interp([]float64{phi}, phis, logNqs)[0])
+}
+
+// CalcUnitBaseResistanceMeyerhof returns the unit base resistance at the given depth by Meyerhof (1976)
+func CalcUnitBaseResistanceMeyerhof(sp ds.SoilProfile, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	if sp.IsCohesive(depth) {
+		return 9 * sp.Cu[layerIndex]
+	}
+	phi := sp.Phi[layerIndex]
+	Nq := CalcMeyerhofNq(phi)
+	limit := 0.5 * sp.AtmosphericPressure() * Nq * math.Tan(phi*math.Pi/180)
+	return math.Min(sp.CalcEffectiveStress(depth)*Nq, limit)
+}
+
+// CalcUnitBaseResistanceVesic returns the unit base resistance at the given depth by the cavity expansion theory of
+// Vesic (1977), neglecting the volumetric strain of the soil
+func CalcUnitBaseResistanceVesic(sp ds.SoilProfile, depth float64) float64 {
+	layerIndex := sp.GetLayerIndex(depth)
+	Es := sp.ElasticModulus[layerIndex]
+	effectiveStress := sp.CalcEffectiveStress(depth)
+	if sp.IsCohesive(depth) {
+		Cu := sp.Cu[layerIndex]
+		Ir := Es / (3 * Cu)
+		Nc := 4.0/3*(math.Log(Ir)+1) + math.Pi/2 + 1
+		return Cu * Nc
+	}
+
+	phi := sp.Phi[layerIndex] * math.Pi / 180
+	nu := sp.PoissonRatio[layerIndex]
+	var c float64
+	if len(sp.Cohesion) > layerIndex {
+		c = sp.Cohesion[layerIndex]
+	}
+	Ir := Es / (2 * (1 + nu) * (c + effectiveStress*math.Tan(phi)))
+	Nsigma := 3 / (3 - math.Sin(phi)) * math.Exp((math.Pi/2-phi)*math.Tan(phi)) *
+		math.Pow(math.Tan(math.Pi/4+phi/2), 2) * math.Pow(Ir, 4*math.Sin(phi)/(3*(1+math.Sin(phi))))
+	K0 := 1 - math.Sin(phi)
+	meanStress := (1 + 2*K0) / 3 * effectiveStress
+	return meanStress * Nsigma
+}
+
+// CalcBaseResistance returns the base resistance of a pile embedded to the given depth by the meyerhof or vesic method
+func CalcBaseResistance(sp ds.SoilProfile, pile ds.PileData, depth float64, method string) float64 {
+	if method == "vesic" {
+		return CalcUnitBaseResistanceVesic(sp, depth) * CalcBaseArea(pile)
+	}
+	return CalcUnitBaseResistanceMeyerhof(sp, depth) * CalcBaseArea(pile)
+}
+
+// GetCapacityDepths returns the depths at which the capacity curve of the pile is evaluated
+func GetCapacityDepths(sp ds.SoilProfile, pile ds.PileData) []float64 {
+	maxDepth := sp.GetLayerDepths()[len(sp.Thickness)-1]
+	if pile.Length > 0 {
+		maxDepth = math.Min(maxDepth, pile.Length)
+	}
+	step := ds.ConvertLength(DepthStep, "m", sp.LengthUnit())
+	depths := np.Arange(step, maxDepth, step)
+	return append(depths, maxDepth)
+}
+
+// CalcAxialCapacity returns the axial capacity of the pile versus depth. clayMethod is alpha or lambda, sandMethod
+// is beta or nordlund and baseMethod is meyerhof or vesic.
+func CalcAxialCapacity(sp ds.SoilProfile, pile ds.PileData, clayMethod, sandMethod, baseMethod string, FS float64) AxialCapacity {
+	var capacity AxialCapacity
+	for _, depth := range GetCapacityDepths(sp, pile) {
+		Qs := CalcShaftResistance(sp, pile, depth, clayMethod, sandMethod)
+		Qb := CalcBaseResistance(sp, pile, depth, baseMethod)
+		capacity.Depth = append(capacity.Depth, depth)
+		capacity.ShaftResistance = append(capacity.ShaftResistance, Qs)
+		capacity.BaseResistance = append(capacity.BaseResistance, Qb)
+		capacity.UltimateCapacity = append(capacity.UltimateCapacity, Qs+Qb)
+		capacity.AllowableCapacity = append(capacity.AllowableCapacity, (Qs+Qb)/FS)
+	}
+	return capacity
+}
@@ -0,0 +1,157 @@
+package retaining_wall
+
+import (
+	"math"
+
+	"github.com/geoport/GeotechnicalSubroutines/bearing_capacity"
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/earth_pressure"
+	"github.com/geoport/GeotechnicalSubroutines/foundation_stability"
+)
+
+// PressureInterval is the depth interval of the earth pressure diagrams acting on the wall
+const PressureInterval = 0.1
+
+// SeismicKhFactor is the ratio of the horizontal seismic coefficient to PGA for walls that can yield
+const SeismicKhFactor = 0.5
+
+// WallCheck is a struct that contains the stability checks of a retaining wall per unit length
+type WallCheck struct {
+	VerticalForce     float64 `json:"vertical_force"`
+	HorizontalForce   float64 `json:"horizontal_force"`
+	ResistingMoment   float64 `json:"resisting_moment"`
+	OverturningMoment float64 `json:"overturning_moment"`
+	OverturningFS     float64 `json:"overturning_fs"`
+	SlidingFS         float64 `json:"sliding_fs"`
+	Eccentricity      float64 `json:"eccentricity"`
+	WithinKern        bool    `json:"within_kern"`
+	MaxBasePressure   float64 `json:"max_base_pressure"`
+	MinBasePressure   float64 `json:"min_base_pressure"`
+	BearingCapacity   float64 `json:"bearing_capacity"`
+	BearingFS         float64 `json:"bearing_fs"`
+}
+
+// WallStability is a struct that contains the static and seismic stability checks of a retaining wall
+type WallStability struct {
+	Static  WallCheck `json:"static"`
+	Seismic WallCheck `json:"seismic"`
+}
+
+// weight is a vertical force with its lever arm from the toe and its height above the base
+type weight struct {
+	W float64
+	X float64
+	Y float64
+}
+
+// GetHeelWidth returns the width of the base behind the stem
+func GetHeelWidth(wall ds.RetainingWallData) float64 {
+	return wall.BaseWidth - wall.ToeWidth - wall.StemBottomWidth
+}
+
+// GetVirtualBackHeight returns the height of the vertical plane through the heel on which the earth pressure acts
+func GetVirtualBackHeight(wall ds.RetainingWallData) float64 {
+	return wall.Height + GetHeelWidth(wall)*math.Tan(wall.BackfillSlope*math.Pi/180)
+}
+
+// getWeights returns the weights of the wall and of the backfill resting on the heel
+func getWeights(backfill ds.SoilProfile, wall ds.RetainingWallData) []weight {
+	B := wall.BaseWidth
+	t := wall.BaseThickness
+	hs := wall.Height - t
+	heel := GetHeelWidth(wall)
+	batter := wall.StemBottomWidth - wall.StemTopWidth
+	gammaWall := wall.UnitWeight * backfill.StressFactor()
+	gammaSoil := backfill.CalcNormalStress(hs) / hs
+	slopeRise := heel * math.Tan(wall.BackfillSlope*math.Pi/180)
+
+	return []weight{
+		{W: gammaWall * B * t, X: B / 2, Y: t / 2},
+		{W: gammaWall * wall.StemTopWidth * hs, X: wall.ToeWidth + wall.StemBottomWidth - wall.StemTopWidth/2, Y: t + hs/2},
+		{W: gammaWall * batter * hs / 2, X: wall.ToeWidth + 2*batter/3, Y: t + hs/3},
+		{W: gammaSoil * heel * hs, X: B - heel/2, Y: t + hs/2},
+		{W: gammaSoil * heel * slopeRise / 2, X: B - heel/3, Y: wall.Height + slopeRise/3},
+	}
+}
+
+// getThrustAngle returns the inclination of the earth thrust from the horizontal, which is parallel to the backfill
+// for cantilever walls and equal to the wall friction for gravity walls
+func getThrustAngle(wall ds.RetainingWallData) float64 {
+	if wall.Type == "gravity" {
+		return wall.WallFriction
+	}
+	return wall.BackfillSlope
+}
+
+// calcWallCheck returns the stability checks of the wall for the given vertical forces, horizontal forces and
+// overturning moment about the toe
+func calcWallCheck(foundation ds.SoilProfile, wall ds.RetainingWallData, V, H, Mr, Mo float64) WallCheck {
+	B := wall.BaseWidth
+	e := B/2 - (Mr-Mo)/V
+	pressure := foundation_stability.CalcBasePressure(V, e, B, 1)
+
+	effectiveWidth := math.Max(B-2*math.Abs(e), 0)
+	inclination := math.Atan(H/V) * 180 / math.Pi
+	qult := bearing_capacity.CalcMeyerhofBearingCapacity(foundation, effectiveWidth, 0, wall.Df, inclination)
+
+	return WallCheck{
+		VerticalForce:     V,
+		HorizontalForce:   H,
+		ResistingMoment:   Mr,
+		OverturningMoment: Mo,
+		OverturningFS:     Mr / Mo,
+		SlidingFS:         V * wall.FrictionCoefficient / H,
+		Eccentricity:      e,
+		WithinKern:        math.Abs(e) <= B/6,
+		MaxBasePressure:   pressure.MaxPressure,
+		MinBasePressure:   pressure.MinPressure,
+		BearingCapacity:   qult,
+		BearingFS:         qult * effectiveWidth / V,
+	}
+}
+
+// CheckRetainingWall returns the overturning, sliding, eccentricity and bearing checks of the retaining wall under
+// static and seismic conditions. The earth pressure acts on the vertical plane through the heel, the passive
+// resistance in front of the toe is neglected and the seismic coefficient is SeismicKhFactor times PGA.
+func CheckRetainingWall(backfill, foundation ds.SoilProfile, wall ds.RetainingWallData, sd ds.SeismicData) WallStability {
+	B := wall.BaseWidth
+	Hv := GetVirtualBackHeight(wall)
+	angle := getThrustAngle(wall)
+	angleRad := angle * math.Pi / 180
+
+	method := "rankine"
+	if wall.Type == "gravity" {
+		method = "coulomb"
+	}
+	thrust := earth_pressure.CalcActivePressure(backfill, Hv, PressureInterval, method, wall.WallFriction, wall.BackfillSlope)
+	thrustHeight := Hv - thrust.ForceDepth
+
+	var V, Mr, inertia, inertiaMoment float64
+	for _, w := range getWeights(backfill, wall) {
+		V += w.W
+		Mr += w.W * w.X
+		inertia += w.W
+		inertiaMoment += w.W * w.Y
+	}
+	V += thrust.VerticalForce
+	Mr += thrust.VerticalForce * B
+	H := thrust.HorizontalForce
+	Mo := thrust.HorizontalForce * thrustHeight
+
+	static := calcWallCheck(foundation, wall, V, H, Mr, Mo)
+
+	kh, kv := earth_pressure.CalcSeismicCoefficients(sd, SeismicKhFactor, 0)
+	dynamic := earth_pressure.CalcMononobeOkabeActive(backfill, Hv, angle, wall.BackfillSlope, kh, kv)
+	dPh := dynamic.DynamicIncrement * math.Cos(angleRad)
+	dPv := dynamic.DynamicIncrement * math.Sin(angleRad)
+	seismic := calcWallCheck(
+		foundation,
+		wall,
+		V+dPv,
+		H+dPh+kh*inertia,
+		Mr+dPv*B,
+		Mo+dPh*dynamic.IncrementHeight+kh*inertiaMoment,
+	)
+
+	return WallStability{Static: static, Seismic: seismic}
+}
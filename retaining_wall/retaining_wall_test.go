@@ -0,0 +1,93 @@
+package retaining_wall
+
+import (
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var backfill = ds.SoilProfile{
+	SoilClass:           []string{"SW"},
+	Thickness:           []float64{20},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{20},
+	Phi:                 []float64{30},
+	Gwt:                 20,
+	CheckGwt:            true,
+}
+
+var foundation = ds.SoilProfile{
+	SoilClass:           []string{"SM"},
+	Thickness:           []float64{20},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{20},
+	Phi:                 []float64{32},
+	Cohesion:            []float64{5},
+	Gwt:                 20,
+	CheckGwt:            true,
+}
+
+var cantileverWall = ds.RetainingWallData{
+	Type:                "cantilever",
+	Height:              6,
+	StemTopWidth:        0.3,
+	StemBottomWidth:     0.5,
+	BaseWidth:           4,
+	BaseThickness:       0.6,
+	ToeWidth:            1,
+	Df:                  1,
+	UnitWeight:          25,
+	FrictionCoefficient: 0.5,
+}
+
+var gravityWall = ds.RetainingWallData{
+	Type:                "gravity",
+	Height:              6,
+	StemTopWidth:        0.6,
+	StemBottomWidth:     2.5,
+	BaseWidth:           3.5,
+	BaseThickness:       0.6,
+	ToeWidth:            0.5,
+	Df:                  1,
+	UnitWeight:          23,
+	WallFriction:        20,
+	FrictionCoefficient: 0.5,
+}
+
+func getCheckValues(check WallCheck) []float64 {
+	return np.Round([]float64{
+		check.VerticalForce,
+		check.HorizontalForce,
+		check.OverturningFS,
+		check.SlidingFS,
+		check.Eccentricity,
+		check.MaxBasePressure,
+		check.MinBasePressure,
+		check.BearingFS,
+	}, 4)
+}
+
+func TestCheckRetainingWall(t *testing.T) {
+	sd := ds.SeismicData{PGA: 0.3}
+
+	output := CheckRetainingWall(backfill, foundation, cantileverWall, sd)
+	expectedStatic := []float64{357, 108, 3.9733, 1.6528, 0.2011, 116.1656, 62.3344, 5.9145}
+	if !reflect.DeepEqual(expectedStatic, getCheckValues(output.Static)) {
+		t.Errorf("Expected %v, got %v", expectedStatic, getCheckValues(output.Static))
+	}
+	expectedSeismic := []float64{357, 193.822, 1.7877, 0.9209, 0.9407, 224.6867, 0, 1.8623}
+	if !reflect.DeepEqual(expectedSeismic, getCheckValues(output.Seismic)) {
+		t.Errorf("Expected %v, got %v", expectedSeismic, getCheckValues(output.Seismic))
+	}
+	if !output.Static.WithinKern || output.Seismic.WithinKern {
+		t.Errorf("Expected the static resultant within the kern and the seismic resultant outside")
+	}
+
+	output = CheckRetainingWall(backfill, foundation, gravityWall, sd)
+	expectedStatic = []float64{322.3567, 90.5203, 4.2391, 1.7806, -0.0691, 103.0129, 81.1909, 6.4259}
+	if !reflect.DeepEqual(expectedStatic, getCheckValues(output.Static)) {
+		t.Errorf("Expected %v, got %v", expectedStatic, getCheckValues(output.Static))
+	}
+}
@@ -0,0 +1,167 @@
+package settlement
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+)
+
+// SublayerThickness is the maximum thickness of the sublayers used in settlement integration
+const SublayerThickness = 0.25
+
+// GetSublayers divides the soil profile between the given levels into sublayers which do not cross layer
+// boundaries and returns the center level and thickness of each sublayer
+func GetSublayers(sp ds.SoilProfile, top, bottom, maxThickness float64) ([]float64, []float64) {
+	var centers, thicknesses []float64
+	boundaries := []float64{top}
+	for _, depth := range sp.GetLayerDepths() {
+		if depth > top && depth < bottom {
+			boundaries = append(boundaries, depth)
+		}
+	}
+	boundaries = append(boundaries, bottom)
+
+	for i := 1; i < len(boundaries); i++ {
+		H := boundaries[i] - boundaries[i-1]
+		if H <= 0 {
+			continue
+		}
+		n := math.Ceil(H / maxThickness)
+		h := H / n
+		for j := 0; j < int(n); j++ {
+			centers = append(centers, boundaries[i-1]+h*(float64(j)+0.5))
+			thicknesses = append(thicknesses, h)
+		}
+	}
+	return centers, thicknesses
+}
+
+// CalcCornerInfluenceFactor returns the Boussinesq influence factor for the vertical stress under the corner
+// of a uniformly loaded rectangle of given dimensions at depth z below the loaded area (Newmark, 1935)
+func CalcCornerInfluenceFactor(width, length, z float64) float64 {
+	if width <= 0 || length <= 0 {
+		return 0
+	}
+	m := width / z
+	n := length / z
+	m2, n2 := m*m, n*n
+	root := math.Sqrt(m2 + n2 + 1)
+	term1 := 2 * m * n * root / (m2 + n2 + m2*n2 + 1) * (m2 + n2 + 2) / (m2 + n2 + 1)
+	term2 := math.Atan2(2*m*n*root, m2+n2+1-m2*n2)
+	return (term1 + term2) / (4 * math.Pi)
+}
+
+// CalcStressIncrease returns the vertical stress increase at depth z below the foundation base by the 2:1 method
+func CalcStressIncrease(bd ds.BuildingData, z float64) float64 {
+	return bd.Q * bd.B * bd.L / ((bd.B + z) * (bd.L + z))
+}
+
+// CalcStressIncreaseAtPoint returns the vertical stress increase at depth z below the foundation base under the
+// point (x, y) of the footprint, where x runs along B and y runs along L from a corner of the foundation
+func CalcStressIncreaseAtPoint(bd ds.BuildingData, x, y, z float64) float64 {
+	I := CalcCornerInfluenceFactor(x, y, z) +
+		CalcCornerInfluenceFactor(bd.B-x, y, z) +
+		CalcCornerInfluenceFactor(x, bd.L-y, z) +
+		CalcCornerInfluenceFactor(bd.B-x, bd.L-y, z)
+	return bd.Q * I
+}
+
+// CalcElasticSettlementAtPoint returns the immediate settlement under the point (x, y) of a flexible foundation
+// by integrating the Boussinesq stress increase over the elastic modulus of the layers below the base
+func CalcElasticSettlementAtPoint(sp ds.SoilProfile, bd ds.BuildingData, x, y float64) float64 {
+	bottom := sp.GetLayerDepths()[len(sp.Thickness)-1]
+	centers, thicknesses := GetSublayers(sp, bd.Df, bottom, SublayerThickness)
+
+	var settlement float64
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		if len(sp.ElasticModulus) <= layerIndex || sp.ElasticModulus[layerIndex] <= 0 {
+			continue
+		}
+		dSigma := CalcStressIncreaseAtPoint(bd, x, y, center-bd.Df)
+		settlement += dSigma * thicknesses[i] / sp.ElasticModulus[layerIndex]
+	}
+	return settlement
+}
+
+// CalcElasticSettlement returns the immediate settlement under the center of the foundation
+func CalcElasticSettlement(sp ds.SoilProfile, bd ds.BuildingData) float64 {
+	return CalcElasticSettlementAtPoint(sp, bd, bd.B/2, bd.L/2)
+}
+
+// CalcConsolidationSettlementAtPoint returns the primary consolidation settlement under the point (x, y) of the
+// foundation. The coefficient of volume compressibility is used for the layers that define it, compression index
+// is used otherwise.
+func CalcConsolidationSettlementAtPoint(sp ds.SoilProfile, bd ds.BuildingData, x, y float64) float64 {
+	bottom := sp.GetLayerDepths()[len(sp.Thickness)-1]
+	centers, thicknesses := GetSublayers(sp, bd.Df, bottom, SublayerThickness)
+
+	var settlement float64
+	for i, center := range centers {
+		dSigma := CalcStressIncreaseAtPoint(bd, x, y, center-bd.Df)
+		settlement += CalcSublayerConsolidation(sp, center, thicknesses[i], dSigma)
+	}
+	return settlement
+}
+
+// CalcConsolidationSettlement returns the primary consolidation settlement under the center of the foundation
+func CalcConsolidationSettlement(sp ds.SoilProfile, bd ds.BuildingData) float64 {
+	return CalcConsolidationSettlementAtPoint(sp, bd, bd.B/2, bd.L/2)
+}
+
+// CalcSublayerConsolidation returns the primary consolidation settlement of a sublayer with the given center
+// level and thickness under the given stress increase. Overconsolidated layers are compressed by Cr up to the
+// preconsolidation pressure and by Cc beyond it.
+func CalcSublayerConsolidation(sp ds.SoilProfile, center, thickness, dSigma float64) float64 {
+	layerIndex := sp.GetLayerIndex(center)
+	if len(sp.Mv) > layerIndex && sp.Mv[layerIndex] > 0 {
+		return sp.Mv[layerIndex] * dSigma * thickness
+	}
+	if len(sp.Cc) > layerIndex && sp.Cc[layerIndex] > 0 && len(sp.VoidRatio) > layerIndex {
+		sigma0 := sp.CalcEffectiveStress(center)
+		sigmaP := sp.CalcPreconsolidationPressure(center)
+		sigma1 := sigma0 + dSigma
+		Cc := sp.Cc[layerIndex]
+		var Cr float64
+		if len(sp.Cr) > layerIndex {
+			Cr = sp.Cr[layerIndex]
+		}
+		e0 := sp.VoidRatio[layerIndex]
+
+		if sigma1 <= sigmaP {
+			return Cr / (1 + e0) * thickness * math.Log10(sigma1/sigma0)
+		}
+		return (Cr*math.Log10(sigmaP/sigma0) + Cc*math.Log10(sigma1/sigmaP)) / (1 + e0) * thickness
+	}
+	return 0
+}
+
+// CalcTotalSettlement returns the sum of immediate and primary consolidation settlements under the center of
+// the foundation
+func CalcTotalSettlement(sp ds.SoilProfile, bd ds.BuildingData) float64 {
+	return CalcElasticSettlement(sp, bd) + CalcConsolidationSettlement(sp, bd)
+}
+
+// CalcSettlementProfile returns the consolidation settlement of the ground at the given depths under a uniform
+// stress increase over a wide area, such as a fill placed on the ground surface
+func CalcSettlementProfile(sp ds.SoilProfile, depths []float64, dSigma float64) []float64 {
+	bottom := sp.GetLayerDepths()[len(sp.Thickness)-1]
+	centers, thicknesses := GetSublayers(sp, 0, bottom, SublayerThickness)
+
+	var compressions []float64
+	for i, center := range centers {
+		compressions = append(compressions, CalcSublayerConsolidation(sp, center, thicknesses[i], dSigma))
+	}
+
+	var settlements []float64
+	for _, depth := range depths {
+		var s float64
+		for i, center := range centers {
+			if center > depth {
+				s += compressions[i]
+			}
+		}
+		settlements = append(settlements, s)
+	}
+	return settlements
+}
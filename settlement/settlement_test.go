@@ -0,0 +1,90 @@
+package settlement
+
+import (
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var soilProfile = ds.SoilProfile{
+	SoilClass:           []string{"SP", "CL"},
+	Thickness:           []float64{4, 6},
+	DryUnitWeight:       []float64{18, 17},
+	SaturatedUnitWeight: []float64{20, 19},
+	ElasticModulus:      []float64{20000, 8000},
+	PoissonRatio:        []float64{0.3, 0.4},
+	Cc:                  []float64{0, 0.3},
+	VoidRatio:           []float64{0.6, 0.9},
+	Gwt:                 20,
+	CheckGwt:            true,
+}
+
+var buildingData = ds.BuildingData{
+	Df: 1,
+	B:  2,
+	L:  3,
+	Q:  100,
+}
+
+func TestGetSublayers(t *testing.T) {
+	expectedCenters := []float64{1.75, 3.25, 4.5}
+	expectedThicknesses := []float64{1.5, 1.5, 1}
+	outputCenters, outputThicknesses := GetSublayers(soilProfile, 1, 5, 1.5)
+	if reflect.DeepEqual(outputCenters, expectedCenters) == false {
+		t.Errorf("Expected %v, got %v", expectedCenters, outputCenters)
+	}
+	if reflect.DeepEqual(outputThicknesses, expectedThicknesses) == false {
+		t.Errorf("Expected %v, got %v", expectedThicknesses, outputThicknesses)
+	}
+}
+
+func TestCalcCornerInfluenceFactor(t *testing.T) {
+	expected := []float64{0.1752, 0.2391, 0}
+	output := np.Round([]float64{
+		CalcCornerInfluenceFactor(1, 1, 1),
+		CalcCornerInfluenceFactor(2, 1, 0.5),
+		CalcCornerInfluenceFactor(0, 1, 1),
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcStressIncrease(t *testing.T) {
+	expected := []float64{30, 42.83}
+	output := np.Round([]float64{
+		CalcStressIncrease(buildingData, 2),
+		CalcStressIncreaseAtPoint(buildingData, 1, 1.5, 2),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcSettlement(t *testing.T) {
+	expected := []float64{0.0162, 0.0354, 0.0516}
+	output := np.Round([]float64{
+		CalcElasticSettlement(soilProfile, buildingData),
+		CalcConsolidationSettlement(soilProfile, buildingData),
+		CalcTotalSettlement(soilProfile, buildingData),
+	}, 4)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcSublayerConsolidation(t *testing.T) {
+	sp := soilProfile
+	sp.Cr = []float64{0, 0.05}
+	var output []float64
+	for _, OCR := range []float64{1, 2, 1.2} {
+		sp.OCR = []float64{1, OCR}
+		output = append(output, CalcSublayerConsolidation(sp, 6, 1, 50))
+	}
+	expected := []float64{0.0265, 0.0044, 0.0161}
+	if !reflect.DeepEqual(expected, np.Round(output, 4)) {
+		t.Errorf("Expected %v, got %v", expected, np.Round(output, 4))
+	}
+}
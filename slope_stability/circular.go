@@ -0,0 +1,431 @@
+package slope_stability
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+// SliceCount is the number of slices of the sliding mass
+const SliceCount = 30
+
+// SurfaceSamples is the number of points used to find the intersections of a slip circle with the ground surface
+const SurfaceSamples = 200
+
+// MaxIterations is the maximum number of iterations of the factor of safety
+const MaxIterations = 100
+
+// Tolerance is the convergence tolerance of the factor of safety
+const Tolerance = 1e-6
+
+// MinSafetyFactor and MaxSafetyFactor are the bounds of the factors of safety searched by the Spencer method
+const (
+	MinSafetyFactor = 0.05
+	MaxSafetyFactor = 100.0
+)
+
+// SearchGrid is a struct that contains the grid of slip circle centres and the number of radii tried at each
+// centre. The radii are set so that the bottoms of the circles are distributed evenly between the bottom of the
+// soil profile and the middle height of the slope.
+type SearchGrid struct {
+	XMin float64 `json:"x_min"`
+	XMax float64 `json:"x_max"`
+	YMin float64 `json:"y_min"`
+	YMax float64 `json:"y_max"`
+	Nx   int     `json:"nx"`
+	Ny   int     `json:"ny"`
+	Nr   int     `json:"nr"`
+}
+
+// SlipCircle is a struct that contains a circular slip surface and its factor of safety
+type SlipCircle struct {
+	Method       string  `json:"method"`
+	CenterX      float64 `json:"center_x"`
+	CenterY      float64 `json:"center_y"`
+	Radius       float64 `json:"radius"`
+	EntryX       float64 `json:"entry_x"`
+	ExitX        float64 `json:"exit_x"`
+	SafetyFactor float64 `json:"safety_factor"`
+	Theta        float64 `json:"theta"`
+}
+
+// Slice is a struct that contains the properties of a slice of the sliding mass
+type Slice struct {
+	X            float64 `json:"x"`
+	Width        float64 `json:"width"`
+	Height       float64 `json:"height"`
+	Weight       float64 `json:"weight"`
+	BaseAngle    float64 `json:"base_angle"`
+	PorePressure float64 `json:"pore_pressure"`
+	Cohesion     float64 `json:"cohesion"`
+	Phi          float64 `json:"phi"`
+}
+
+// interpolate returns the value of the polyline at x
+func interpolate(line ds.Polyline, x float64) float64 {
+	return np.Interp([]float64{x}, line.X, line.Y)[0]
+}
+
+// getTopElevation returns the elevation of the highest point of the ground surface
+func getTopElevation(slope ds.SlopeData) float64 {
+	top, _ := np.Max(slope.Surface.Y)
+	return top
+}
+
+// getLayerBottoms returns the elevations of the bottoms of the layers at x
+func getLayerBottoms(sp ds.SoilProfile, slope ds.SlopeData, x float64) []float64 {
+	var bottoms []float64
+	if len(slope.LayerBoundaries) == len(sp.Thickness) {
+		for _, boundary := range slope.LayerBoundaries {
+			bottoms = append(bottoms, interpolate(boundary, x))
+		}
+		return bottoms
+	}
+	top := getTopElevation(slope)
+	for _, depth := range sp.GetLayerDepths() {
+		bottoms = append(bottoms, top-depth)
+	}
+	return bottoms
+}
+
+// getPhreaticElevation returns the elevation of the phreatic line at x, below which the saturated unit weights are
+// used. It is horizontal at Gwt below the highest point of the ground surface and follows the ground surface where
+// the ground is lower, as in the total stresses of the soil profile.
+func getPhreaticElevation(sp ds.SoilProfile, slope ds.SlopeData, x float64) float64 {
+	return math.Min(getTopElevation(slope)-sp.Gwt, interpolate(slope.Surface, x))
+}
+
+// getBasePorePressure returns the pore pressure at the base of a slice in the layer with the given index from the
+// pore water definition of the soil profile at the depth below the highest point of the ground surface. It is
+// limited to the hydrostatic pressure below the ground surface where the ground is lower, as the water seeps out
+// of the slope face.
+func getBasePorePressure(sp ds.SoilProfile, slope ds.SlopeData, x, base float64, layerIndex int) float64 {
+	u := sp.CalcLayerPorePressure(getTopElevation(slope)-base, layerIndex)
+	return math.Min(u, sp.WaterUnitWeight()*math.Max(interpolate(slope.Surface, x)-base, 0))
+}
+
+// getCircleElevation returns the elevation of the lower half of the circle at x
+func getCircleElevation(cx, cy, R, x float64) float64 {
+	return cy - math.Sqrt(math.Max(R*R-(x-cx)*(x-cx), 0))
+}
+
+// GetSlipExtent returns the entry and exit abscissas of the longest part of the circle below the ground surface and
+// whether the circle cuts the ground surface
+func GetSlipExtent(slope ds.SlopeData, cx, cy, R float64) (float64, float64, bool) {
+	surface := slope.Surface
+	xMin := math.Max(cx-R, surface.X[0])
+	xMax := math.Min(cx+R, surface.X[len(surface.X)-1])
+	if xMax <= xMin {
+		return 0, 0, false
+	}
+
+	xs := np.LinSpace(xMin, xMax, SurfaceSamples+1)
+	var entry, exit, start float64
+	inside := false
+	for i, x := range xs {
+		below := getCircleElevation(cx, cy, R, x) < interpolate(surface, x)
+		if below && !inside {
+			start = x
+			if i > 0 {
+				start = (xs[i-1] + x) / 2
+			}
+			inside = true
+		}
+		if inside && (!below || i == len(xs)-1) {
+			end := x
+			if !below {
+				end = (xs[i-1] + x) / 2
+			}
+			if end-start > exit-entry {
+				entry, exit = start, end
+			}
+			inside = false
+		}
+	}
+	return entry, exit, exit > entry
+}
+
+// GetSlices returns the slices of the sliding mass above the circle between the entry and exit abscissas. The base
+// angles are positive where the base dips in the direction of sliding, which is towards the lower side of the slope,
+// so that the weight of these slices drives the sliding.
+func GetSlices(sp ds.SoilProfile, slope ds.SlopeData, cx, cy, R, entry, exit float64) []Slice {
+	surface := slope.Surface
+	direction := 1.0
+	if surface.Y[0] < surface.Y[len(surface.Y)-1] {
+		direction = -1
+	}
+	width := (exit - entry) / SliceCount
+
+	var slices []Slice
+	for i := 0; i < SliceCount; i++ {
+		x := entry + (float64(i)+0.5)*width
+		top := interpolate(surface, x)
+		base := getCircleElevation(cx, cy, R, x)
+		water := getPhreaticElevation(sp, slope, x)
+		bottoms := getLayerBottoms(sp, slope, x)
+
+		// weight of the column from the top of each layer to its bottom
+		var weight float64
+		layerTop := top
+		baseLayer := len(bottoms) - 1
+		for j, bottom := range bottoms {
+			upper := math.Min(layerTop, top)
+			lower := math.Max(bottom, base)
+			if upper > lower {
+				dry := math.Max(upper-math.Max(lower, water), 0)
+				saturated := upper - lower - dry
+				weight += (sp.DryUnitWeight[j]*dry + sp.SaturatedUnitWeight[j]*saturated) * width * sp.StressFactor()
+			}
+			if base >= bottom && baseLayer == len(bottoms)-1 {
+				baseLayer = j
+			}
+			layerTop = bottom
+		}
+
+		slices = append(slices, Slice{
+			X:            x,
+			Width:        width,
+			Height:       top - base,
+			Weight:       weight,
+			BaseAngle:    math.Asin(direction * (cx - x) / R),
+			PorePressure: getBasePorePressure(sp, slope, x, base, baseLayer),
+			Cohesion:     sp.GetCohesion(baseLayer),
+			Phi:          sp.Phi[baseLayer],
+		})
+	}
+	return slices
+}
+
+// calcBishop returns the factor of safety of the slices by the simplified Bishop method
+func calcBishop(slices []Slice) float64 {
+	var driving float64
+	for _, s := range slices {
+		driving += s.Weight * math.Sin(s.BaseAngle)
+	}
+	FS := 1.0
+	for iteration := 0; iteration < MaxIterations; iteration++ {
+		var resisting float64
+		for _, s := range slices {
+			tanPhi := math.Tan(s.Phi * math.Pi / 180)
+			mAlpha := math.Cos(s.BaseAngle) + math.Sin(s.BaseAngle)*tanPhi/FS
+			resisting += (s.Cohesion*s.Width + (s.Weight-s.PorePressure*s.Width)*tanPhi) / mAlpha
+		}
+		newFS := resisting / driving
+		if math.Abs(newFS-FS) < Tolerance {
+			return newFS
+		}
+		FS = newFS
+	}
+	return FS
+}
+
+// calcJanbuCorrection returns the correction factor f0 of the simplified Janbu method for the depth to length ratio
+// of the sliding mass
+func calcJanbuCorrection(slices []Slice, length float64) float64 {
+	var depth, c, phi float64
+	for _, s := range slices {
+		depth = math.Max(depth, s.Height)
+		c += s.Cohesion
+		phi += s.Phi
+	}
+	b1 := 0.69
+	if c == 0 {
+		b1 = 0.5
+	} else if phi == 0 {
+		b1 = 0.31
+	}
+	ratio := depth / length
+	return 1 + b1*(ratio-1.4*ratio*ratio)
+}
+
+// calcJanbu returns the factor of safety of the slices by the simplified Janbu method with the correction factor f0
+func calcJanbu(slices []Slice) float64 {
+	var driving float64
+	for _, s := range slices {
+		driving += s.Weight * math.Tan(s.BaseAngle)
+	}
+	FS := 1.0
+	for iteration := 0; iteration < MaxIterations; iteration++ {
+		var resisting float64
+		for _, s := range slices {
+			tanPhi := math.Tan(s.Phi * math.Pi / 180)
+			nAlpha := math.Pow(math.Cos(s.BaseAngle), 2) * (1 + math.Tan(s.BaseAngle)*tanPhi/FS)
+			resisting += (s.Cohesion*s.Width + (s.Weight-s.PorePressure*s.Width)*tanPhi) / nAlpha
+		}
+		newFS := resisting / driving
+		if math.Abs(newFS-FS) < Tolerance {
+			FS = newFS
+			break
+		}
+		FS = newFS
+	}
+	length := slices[len(slices)-1].X - slices[0].X + slices[0].Width
+	return FS * calcJanbuCorrection(slices, length)
+}
+
+// calcSpencerForces returns the sum of the interslice force resultants and the sum of their moments about the
+// centre of the circle divided by the radius, for the factor of safety FS and the interslice force inclination theta
+func calcSpencerForces(slices []Slice, FS, theta float64) (float64, float64) {
+	var force, moment float64
+	for _, s := range slices {
+		alpha := s.BaseAngle
+		tanPhi := math.Tan(s.Phi * math.Pi / 180)
+		baseLength := s.Width / math.Cos(alpha)
+		Q := (s.Cohesion*baseLength/FS + (s.Weight*math.Cos(alpha)-s.PorePressure*baseLength)*tanPhi/FS - s.Weight*math.Sin(alpha)) /
+			(math.Cos(alpha-theta) * (1 + math.Tan(alpha-theta)*tanPhi/FS))
+		force += Q
+		moment += Q * math.Cos(alpha-theta)
+	}
+	return force, moment
+}
+
+// isSpencerAdmissible returns true if the normal forces on the bases of all slices are compatible with the factor of
+// safety FS and the interslice force inclination theta in degrees, which excludes the roots at the poles of the
+// Spencer equations
+func isSpencerAdmissible(slices []Slice, FS, theta float64) bool {
+	if math.IsNaN(FS) {
+		return false
+	}
+	for _, s := range slices {
+		alpha := s.BaseAngle - theta*math.Pi/180
+		tanPhi := math.Tan(s.Phi * math.Pi / 180)
+		if math.Cos(alpha)*(1+math.Tan(alpha)*tanPhi/FS) < 0.2 {
+			return false
+		}
+	}
+	return true
+}
+
+// solveSafetyFactor returns the factor of safety at which the given function of the factor of safety vanishes. The
+// function is negative for large factors of safety and the root is bracketed by decreasing the factor of safety
+// from MaxSafetyFactor, so that the poles of the Spencer equations at small factors of safety are not crossed.
+func solveSafetyFactor(f func(float64) float64) float64 {
+	high := MaxSafetyFactor
+	if !(f(high) < 0) {
+		return math.NaN()
+	}
+	low := high
+	for {
+		low *= 0.9
+		if low < MinSafetyFactor {
+			return math.NaN()
+		}
+		value := f(low)
+		if value > 0 {
+			break
+		}
+		high = low
+	}
+	for iteration := 0; iteration < MaxIterations && high-low > Tolerance; iteration++ {
+		mid := (low + high) / 2
+		if f(mid) > 0 {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// calcSpencer returns the factor of safety and the interslice force inclination in degrees of the slices by the
+// Spencer method, where the inclination is the one at which the factors of safety for force and moment equilibrium
+// are equal
+func calcSpencer(slices []Slice) (float64, float64) {
+	factors := func(theta float64) (float64, float64) {
+		Ff := solveSafetyFactor(func(FS float64) float64 {
+			force, _ := calcSpencerForces(slices, FS, theta*math.Pi/180)
+			return force
+		})
+		Fm := solveSafetyFactor(func(FS float64) float64 {
+			_, moment := calcSpencerForces(slices, FS, theta*math.Pi/180)
+			return moment
+		})
+		if !isSpencerAdmissible(slices, Ff, theta) || !isSpencerAdmissible(slices, Fm, theta) {
+			return math.NaN(), math.NaN()
+		}
+		return Ff, Fm
+	}
+
+	// the inclinations are scanned outwards from zero in steps of one degree to bracket the equality of the factors
+	// of safety closest to horizontal interslice forces
+	var low, high, lowDiff float64
+	found := false
+	zeroFf, zeroFm := factors(0)
+	zeroDiff := zeroFf - zeroFm
+	for _, direction := range []float64{1, -1} {
+		prevTheta, prevDiff := 0.0, zeroDiff
+		for step := 1.0; step <= 45 && !found; step++ {
+			theta := direction * step
+			Ff, Fm := factors(theta)
+			diff := Ff - Fm
+			if !math.IsNaN(prevDiff) && !math.IsNaN(diff) && prevDiff*diff <= 0 {
+				low, high, lowDiff = prevTheta, theta, prevDiff
+				found = true
+			}
+			prevTheta, prevDiff = theta, diff
+		}
+	}
+	if !found {
+		return math.NaN(), math.NaN()
+	}
+
+	for iteration := 0; iteration < MaxIterations && high-low > Tolerance; iteration++ {
+		mid := (low + high) / 2
+		Ff, Fm := factors(mid)
+		if (Ff-Fm)*lowDiff > 0 {
+			low, lowDiff = mid, Ff-Fm
+		} else {
+			high = mid
+		}
+	}
+	theta := (low + high) / 2
+	_, Fm := factors(theta)
+	return Fm, theta
+}
+
+// CalcSlipCircle returns the factor of safety of the slip circle with the given centre and radius by the bishop,
+// janbu or spencer method. The factor of safety is infinite when the circle does not cut the ground surface.
+func CalcSlipCircle(sp ds.SoilProfile, slope ds.SlopeData, cx, cy, R float64, method string) SlipCircle {
+	circle := SlipCircle{Method: method, CenterX: cx, CenterY: cy, Radius: R, SafetyFactor: math.Inf(1)}
+	entry, exit, ok := GetSlipExtent(slope, cx, cy, R)
+	if !ok {
+		return circle
+	}
+	circle.EntryX, circle.ExitX = entry, exit
+
+	slices := GetSlices(sp, slope, cx, cy, R, entry, exit)
+	switch method {
+	case "janbu":
+		circle.SafetyFactor = calcJanbu(slices)
+	case "spencer":
+		circle.SafetyFactor, circle.Theta = calcSpencer(slices)
+	default:
+		circle.SafetyFactor = calcBishop(slices)
+	}
+	if math.IsNaN(circle.SafetyFactor) || circle.SafetyFactor <= 0 {
+		circle.SafetyFactor = math.Inf(1)
+	}
+	return circle
+}
+
+// CalcCircularSlopeStability returns the critical slip circle with the minimum factor of safety over the search
+// grid by the bishop, janbu or spencer method
+func CalcCircularSlopeStability(sp ds.SoilProfile, slope ds.SlopeData, grid SearchGrid, method string) SlipCircle {
+	bottom := getTopElevation(slope) - np.Sum(sp.Thickness)
+	lowest, _ := np.Min(slope.Surface.Y)
+	middle := (getTopElevation(slope) + lowest) / 2
+
+	critical := SlipCircle{Method: method, SafetyFactor: math.Inf(1)}
+	for _, cx := range np.LinSpace(grid.XMin, grid.XMax, float64(grid.Nx)) {
+		for _, cy := range np.LinSpace(grid.YMin, grid.YMax, float64(grid.Ny)) {
+			for _, circleBottom := range np.LinSpace(bottom, middle, float64(grid.Nr)) {
+				circle := CalcSlipCircle(sp, slope, cx, cy, cy-circleBottom, method)
+				if circle.SafetyFactor < critical.SafetyFactor {
+					critical = circle
+				}
+			}
+		}
+	}
+	return critical
+}
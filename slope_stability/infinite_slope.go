@@ -0,0 +1,132 @@
+package slope_stability
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// PlaneAngleStep is the increment of the inclination of the trial failure planes in degrees
+const PlaneAngleStep = 0.5
+
+// InfiniteSlope is a struct that contains the stability of an infinite slope on a failure plane parallel to the
+// ground surface
+type InfiniteSlope struct {
+	Condition          string  `json:"condition"`
+	Depth              float64 `json:"depth"`
+	NormalStress       float64 `json:"normal_stress"`
+	ShearStress        float64 `json:"shear_stress"`
+	PorePressure       float64 `json:"pore_pressure"`
+	SafetyFactor       float64 `json:"safety_factor"`
+	YieldCoefficient   float64 `json:"yield_coefficient"`
+	SeismicCoefficient float64 `json:"seismic_coefficient"`
+}
+
+// PlanarFailure is a struct that contains the critical planar failure wedge of a finite slope
+type PlanarFailure struct {
+	PlaneAngle   float64 `json:"plane_angle"`
+	Weight       float64 `json:"weight"`
+	PlaneLength  float64 `json:"plane_length"`
+	SafetyFactor float64 `json:"safety_factor"`
+}
+
+// getVerticalStresses returns the vertical total stress and the hydrostatic pore pressure at the given depth for
+// the dry, submerged or seepage condition. The water table is at the ground surface when submerged, at Gwt when
+// seepage is parallel to the slope and below the failure plane when dry.
+func getVerticalStresses(sp ds.SoilProfile, depth float64, condition string) (float64, float64) {
+	switch condition {
+	case "dry":
+		sp.Gwt = math.Inf(1)
+		sp.CheckGwt = false
+	case "submerged":
+		sp.Gwt = 0
+		sp.CheckGwt = true
+		sp.PoreWaterType = nil
+	}
+	return sp.CalcNormalStress(depth), sp.CalcPorePressure(depth)
+}
+
+// CalcInfiniteSlope returns the factor of safety of an infinite slope inclined at the slope angle of the building
+// data, on a failure plane at the given depth, for the dry, submerged or seepage condition and a horizontal seismic
+// coefficient kh. A submerged slope has no seepage and the seepage is parallel to the slope below Gwt in the
+// hydrostatic layers, while the other layers take the pore pressure of their pore water definition.
+func CalcInfiniteSlope(sp ds.SoilProfile, bd ds.BuildingData, depth float64, condition string, kh float64) InfiniteSlope {
+	beta := bd.SlopeAngle * math.Pi / 180
+	layerIndex := sp.GetLayerIndex(depth)
+	c := sp.GetCohesion(layerIndex)
+	tanPhi := math.Tan(sp.Phi[layerIndex] * math.Pi / 180)
+
+	sigmaV, uV := getVerticalStresses(sp, depth, condition)
+	var u float64
+	switch {
+	case condition == "submerged":
+		// the buoyancy acts vertically on the soil column
+		sigmaV -= uV
+	case sp.GetPoreWaterType(layerIndex) == "hydrostatic":
+		u = uV * math.Pow(math.Cos(beta), 2)
+	default:
+		u = uV
+	}
+
+	sin, cos := math.Sin(beta), math.Cos(beta)
+	normal := sigmaV * (cos*cos - kh*sin*cos)
+	shear := sigmaV * (sin*cos + kh*cos*cos)
+	resistance := c + (normal-u)*tanPhi
+	ky := (c + (sigmaV*cos*cos-u)*tanPhi - sigmaV*sin*cos) / (sigmaV * (cos*cos + sin*cos*tanPhi))
+
+	return InfiniteSlope{
+		Condition:          condition,
+		Depth:              depth,
+		NormalStress:       normal,
+		ShearStress:        shear,
+		PorePressure:       u,
+		SafetyFactor:       resistance / shear,
+		YieldCoefficient:   math.Max(ky, 0),
+		SeismicCoefficient: kh,
+	}
+}
+
+// CalcPseudoStaticInfiniteSlope returns the factor of safety of an infinite slope under a horizontal seismic
+// coefficient of khFactor times PGA
+func CalcPseudoStaticInfiniteSlope(sp ds.SoilProfile, bd ds.BuildingData, sd ds.SeismicData, depth float64, condition string, khFactor float64) InfiniteSlope {
+	return CalcInfiniteSlope(sp, bd, depth, condition, khFactor*sd.PGA)
+}
+
+// GetAverageStrength returns the thickness weighted cohesion and friction angle of the soil profile down to the
+// given depth
+func GetAverageStrength(sp ds.SoilProfile, depth float64) (float64, float64) {
+	centers, thicknesses := settlement.GetSublayers(sp, 0, depth, settlement.SublayerThickness)
+	var c, phi float64
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		c += sp.GetCohesion(layerIndex) * thicknesses[i]
+		phi += sp.Phi[layerIndex] * thicknesses[i]
+	}
+	return c / depth, phi / depth
+}
+
+// CalcPlanarFailure returns the critical planar failure wedge through the toe of a dry slope of height H inclined
+// at the slope angle of the building data (Culmann, 1866) under the horizontal seismic coefficient kh. The trial
+// planes are inclined from PlaneAngleStep up to the slope angle.
+func CalcPlanarFailure(sp ds.SoilProfile, bd ds.BuildingData, H, kh float64) PlanarFailure {
+	beta := bd.SlopeAngle * math.Pi / 180
+	c, phi := GetAverageStrength(sp, H)
+	tanPhi := math.Tan(phi * math.Pi / 180)
+	sp.Gwt = math.Inf(1)
+	gamma := sp.CalcNormalStress(H) / H
+
+	critical := PlanarFailure{SafetyFactor: math.Inf(1)}
+	for angle := PlaneAngleStep; angle < bd.SlopeAngle; angle += PlaneAngleStep {
+		theta := angle * math.Pi / 180
+		W := 0.5 * gamma * H * H * math.Sin(beta-theta) / (math.Sin(beta) * math.Sin(theta))
+		L := H / math.Sin(theta)
+		N := W*math.Cos(theta) - kh*W*math.Sin(theta)
+		T := W*math.Sin(theta) + kh*W*math.Cos(theta)
+		FS := (c*L + N*tanPhi) / T
+		if FS < critical.SafetyFactor {
+			critical = PlanarFailure{PlaneAngle: angle, Weight: W, PlaneLength: L, SafetyFactor: FS}
+		}
+	}
+	return critical
+}
@@ -0,0 +1,33 @@
+package slope_stability
+
+import (
+	"math"
+)
+
+// CalcJibson returns the Newmark sliding block displacement in metres from the ratio of the yield acceleration to
+// the peak ground acceleration by the regression of Jibson (2007)
+func CalcJibson(ky, pga float64) float64 {
+	ratio := ky / pga
+	if ratio >= 1 {
+		return 0
+	}
+	logD := 0.215 + math.Log10(math.Pow(1-ratio, 2.341)*math.Pow(ratio, -1.438))
+	return math.Pow(10, logD) / 100
+}
+
+// CalcBrayTravasarou returns the median seismic displacement of a sliding mass in metres and the probability of
+// negligible displacement by Bray and Travasarou (2007). ky is the yield coefficient, Ts is the fundamental period
+// of the sliding mass, Sa is the spectral acceleration at 1.5Ts in g and Mw is the moment magnitude.
+func CalcBrayTravasarou(ky, Ts, Sa, Mw float64) (float64, float64) {
+	lnKy := math.Log(ky)
+	lnSa := math.Log(Sa)
+
+	lnD := -1.10 - 2.83*lnKy - 0.333*lnKy*lnKy + 0.566*lnKy*lnSa + 3.04*lnSa - 0.244*lnSa*lnSa + 1.50*Ts + 0.278*(Mw-7)
+	if Ts < 0.05 {
+		lnD = -0.22 - 2.83*lnKy - 0.333*lnKy*lnKy + 0.566*lnKy*lnSa + 3.04*lnSa - 0.244*lnSa*lnSa + 0.278*(Mw-7)
+	}
+
+	x := -1.76 - 3.22*lnKy - 0.484*Ts*lnKy + 3.52*lnSa
+	probabilityZero := 1 - 0.5*(1+math.Erf(x/math.Sqrt2))
+	return math.Exp(lnD) / 100, probabilityZero
+}
@@ -0,0 +1,122 @@
+package slope_stability
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var sandSlope = ds.SoilProfile{
+	SoilClass:           []string{"SW"},
+	Thickness:           []float64{10},
+	DryUnitWeight:       []float64{18},
+	SaturatedUnitWeight: []float64{20},
+	Phi:                 []float64{35},
+	Cohesion:            []float64{0},
+	Gwt:                 0,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+func TestCalcInfiniteSlope(t *testing.T) {
+	bd := ds.BuildingData{SlopeAngle: 25}
+	var output []float64
+	for _, condition := range []string{"dry", "submerged", "seepage"} {
+		output = append(output, CalcInfiniteSlope(sandSlope, bd, 3, condition, 0).SafetyFactor)
+	}
+	pseudoStatic := CalcPseudoStaticInfiniteSlope(sandSlope, bd, ds.SeismicData{PGA: 0.3}, 3, "dry", 0.5)
+	output = append(output, pseudoStatic.SafetyFactor, pseudoStatic.YieldCoefficient)
+
+	// tan(phi)/tan(beta) for dry and submerged cohesionless slopes and tan(phi-beta) for the yield coefficient
+	expected := []float64{1.5016, 1.5016, 0.7653, 1.0567, 0.1763}
+	if !reflect.DeepEqual(expected, np.Round(output, 4)) {
+		t.Errorf("Expected %v, got %v", expected, np.Round(output, 4))
+	}
+}
+
+func TestCalcInfiniteSlope_Piezometric(t *testing.T) {
+	sp := sandSlope
+	sp.PoreWaterType = []string{"piezometric"}
+	sp.PiezometricLevel = []float64{0}
+	bd := ds.BuildingData{SlopeAngle: 25}
+
+	// the pore pressure of the piezometric level is not reduced for seepage parallel to the slope
+	expected := []float64{29.42, 0.6052}
+	result := CalcInfiniteSlope(sp, bd, 3, "seepage", 0)
+	output := np.Round([]float64{result.PorePressure, result.SafetyFactor}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestCalcPlanarFailure(t *testing.T) {
+	sp := sandSlope
+	sp.Cohesion = []float64{10}
+	output := CalcPlanarFailure(sp, ds.BuildingData{SlopeAngle: 60}, 8, 0)
+	expected := []float64{43.5, 1.3531}
+	result := np.Round([]float64{output.PlaneAngle, output.SafetyFactor}, 4)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestNewmarkDisplacement(t *testing.T) {
+	bt, probabilityZero := CalcBrayTravasarou(0.1, 0.2, 0.6, 7)
+	expected := []float64{0.0308, 0, 0.2008, 0}
+	output := np.Round([]float64{CalcJibson(0.1, 0.3), CalcJibson(0.3, 0.3), bt, probabilityZero}, 4)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+var layeredSlope = ds.SoilProfile{
+	SoilClass:           []string{"CL", "SC"},
+	Thickness:           []float64{6, 14},
+	DryUnitWeight:       []float64{18, 19},
+	SaturatedUnitWeight: []float64{20, 20},
+	Phi:                 []float64{30, 32},
+	Cohesion:            []float64{10, 15},
+	Gwt:                 4,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+var slopeSection = ds.SlopeData{
+	Surface: ds.Polyline{X: []float64{0, 20, 40, 70}, Y: []float64{10, 10, 0, 0}},
+}
+
+func TestCalcSlipCircle(t *testing.T) {
+	var output []float64
+	for _, method := range []string{"bishop", "janbu", "spencer"} {
+		output = append(output, CalcSlipCircle(layeredSlope, slopeSection, 38, 26, 26, method).SafetyFactor)
+	}
+	// the same slope facing the other direction
+	mirrored := ds.SlopeData{Surface: ds.Polyline{X: []float64{0, 30, 50, 70}, Y: []float64{0, 0, 10, 10}}}
+	output = append(output, CalcSlipCircle(layeredSlope, mirrored, 32, 26, 26, "bishop").SafetyFactor)
+
+	expected := []float64{1.5875, 1.6631, 1.5907, 1.5875}
+	if !reflect.DeepEqual(expected, np.Round(output, 4)) {
+		t.Errorf("Expected %v, got %v", expected, np.Round(output, 4))
+	}
+
+	// a circle above the ground surface does not slide
+	circle := CalcSlipCircle(layeredSlope, slopeSection, 30, 30, 5, "bishop")
+	if !math.IsInf(circle.SafetyFactor, 1) {
+		t.Errorf("Expected %v, got %v", math.Inf(1), circle.SafetyFactor)
+	}
+}
+
+func TestCalcCircularSlopeStability(t *testing.T) {
+	grid := SearchGrid{XMin: 30, XMax: 40, YMin: 16, YMax: 28, Nx: 6, Ny: 7, Nr: 5}
+	output := CalcCircularSlopeStability(layeredSlope, slopeSection, grid, "bishop")
+	expected := []float64{36, 16, 18.5, 1.6574}
+	result := np.Round([]float64{output.CenterX, output.CenterY, output.Radius, output.SafetyFactor}, 4)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
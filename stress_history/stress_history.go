@@ -0,0 +1,108 @@
+package stress_history
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+)
+
+// SHANSEP parameters of the normalized undrained strength Cu/σ'v = S OCR^m (Ladd, 1991)
+const (
+	ShansepS = 0.22
+	ShansepM = 0.8
+)
+
+// CPTPreconsolidationFactor is the ratio of the preconsolidation pressure to the net cone resistance of clays
+// (Mayne, 2007)
+const CPTPreconsolidationFactor = 0.33
+
+// SPTPreconsolidationFactor is the ratio of the preconsolidation pressure to N60 times the atmospheric pressure of
+// clays (Mayne, 2012)
+const SPTPreconsolidationFactor = 0.47
+
+// OCRProfile is a struct that contains the stress history of the soil profile at the given depths
+type OCRProfile struct {
+	Depth            []float64 `json:"depth"`
+	EffectiveStress  []float64 `json:"effective_stress"`
+	Preconsolidation []float64 `json:"preconsolidation_pressure"`
+	OCR              []float64 `json:"OCR"`
+}
+
+// calcOCRProfile returns the stress history at the given depths for the preconsolidation pressures returned by
+// the given function, which are not lower than the effective stress
+func calcOCRProfile(sp ds.SoilProfile, depths []float64, preconsolidation func(int, float64) float64) OCRProfile {
+	profile := OCRProfile{}
+	for i, depth := range depths {
+		sigma := sp.CalcEffectiveStress(depth)
+		sigmaP := math.Max(preconsolidation(i, depth), sigma)
+		OCR := 1.0
+		if sigma > 0 {
+			OCR = sigmaP / sigma
+		}
+		profile.Depth = append(profile.Depth, depth)
+		profile.EffectiveStress = append(profile.EffectiveStress, sigma)
+		profile.Preconsolidation = append(profile.Preconsolidation, sigmaP)
+		profile.OCR = append(profile.OCR, OCR)
+	}
+	return profile
+}
+
+// CalcOCRProfile returns the stress history at the given depths from the OCR or preconsolidation pressures of the
+// layers
+func CalcOCRProfile(sp ds.SoilProfile, depths []float64) OCRProfile {
+	return calcOCRProfile(sp, depths, func(_ int, depth float64) float64 {
+		return sp.CalcPreconsolidationPressure(depth)
+	})
+}
+
+// EstimateOCRFromCu returns the stress history at the given depths from the undrained shear strength of the layers
+// by inverting the SHANSEP relationship
+func EstimateOCRFromCu(sp ds.SoilProfile, depths []float64) OCRProfile {
+	return calcOCRProfile(sp, depths, func(_ int, depth float64) float64 {
+		layerIndex := sp.GetLayerIndex(depth)
+		if len(sp.Cu) <= layerIndex {
+			return 0
+		}
+		sigma := sp.CalcEffectiveStress(depth)
+		OCR := math.Pow(sp.Cu[layerIndex]/(ShansepS*sigma), 1/ShansepM)
+		return OCR * sigma
+	})
+}
+
+// EstimateOCRFromCPT returns the stress history at the depths of the CPT log from the net cone resistance, where the
+// cone resistance is taken as corrected for the pore pressure acting behind the cone
+func EstimateOCRFromCPT(sp ds.SoilProfile, cptLog ds.CPTData) OCRProfile {
+	return calcOCRProfile(sp, cptLog.Depth, func(i int, depth float64) float64 {
+		return CPTPreconsolidationFactor * (cptLog.ConeResistance[i] - sp.CalcNormalStress(depth))
+	})
+}
+
+// EstimateOCRFromSPT returns the stress history at the depths of the SPT log from the blow counts corrected to 60%
+// energy efficiency
+func EstimateOCRFromSPT(sp ds.SoilProfile, sptLog ds.SPTData) OCRProfile {
+	return calcOCRProfile(sp, sptLog.Depth, func(_ int, depth float64) float64 {
+		return SPTPreconsolidationFactor * sptLog.GetN60(depth, sp.LengthUnit()) * sp.AtmosphericPressure()
+	})
+}
+
+// GetLayerOCR returns the average OCR of the estimates in each layer of the soil profile, which can be assigned to
+// the OCR of the layers. Layers without estimates are normally consolidated.
+func GetLayerOCR(sp ds.SoilProfile, profile OCRProfile) []float64 {
+	sums := make([]float64, len(sp.Thickness))
+	counts := make([]float64, len(sp.Thickness))
+	for i, depth := range profile.Depth {
+		layerIndex := sp.GetLayerIndex(depth)
+		sums[layerIndex] += profile.OCR[i]
+		counts[layerIndex]++
+	}
+
+	var OCR []float64
+	for i := range sums {
+		if counts[i] == 0 {
+			OCR = append(OCR, 1)
+		} else {
+			OCR = append(OCR, sums[i]/counts[i])
+		}
+	}
+	return OCR
+}
@@ -0,0 +1,52 @@
+package stress_history
+
+import (
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var clayProfile = ds.SoilProfile{
+	SoilClass:           []string{"CH", "CL"},
+	Thickness:           []float64{4, 8},
+	DryUnitWeight:       []float64{17, 18},
+	SaturatedUnitWeight: []float64{18, 19},
+	Cu:                  []float64{40, 60},
+	OCR:                 []float64{3, 0},
+	Gwt:                 2,
+	CheckGwt:            true,
+	DensityUnit:         "kN/m3",
+	PressureUnit:        "kPa",
+}
+
+func TestCalcOCRProfile(t *testing.T) {
+	output := CalcOCRProfile(clayProfile, []float64{1, 6})
+	expected := []float64{3, 1}
+	if !reflect.DeepEqual(expected, np.Round(output.OCR, 4)) {
+		t.Errorf("Expected %v, got %v", expected, np.Round(output.OCR, 4))
+	}
+}
+
+func TestEstimateOCR(t *testing.T) {
+	depths := []float64{1, 3, 6, 10}
+	cu := EstimateOCRFromCu(clayProfile, depths)
+	cpt := EstimateOCRFromCPT(clayProfile, ds.CPTData{Depth: []float64{3, 6}, ConeResistance: []float64{800, 1000}})
+	spt := EstimateOCRFromSPT(clayProfile, ds.SPTData{Depth: []float64{3, 6}, N: []int{6, 8}})
+
+	var output [][]float64
+	for _, profile := range []OCRProfile{cu, cpt, spt} {
+		output = append(output, np.Round(profile.OCR, 4))
+	}
+	expected := [][]float64{{19.3413, 6.2086, 5.5961, 3.2761}, {5.8502, 4.2801}, {6.6835, 5.4672}}
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	expectedLayerOCR := []float64{12.7749, 4.4361}
+	layerOCR := np.Round(GetLayerOCR(clayProfile, cu), 4)
+	if !reflect.DeepEqual(expectedLayerOCR, layerOCR) {
+		t.Errorf("Expected %v, got %v", expectedLayerOCR, layerOCR)
+	}
+}
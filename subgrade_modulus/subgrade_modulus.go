@@ -0,0 +1,108 @@
+package subgrade_modulus
+
+import (
+	"math"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	"github.com/geoport/GeotechnicalSubroutines/settlement"
+)
+
+// bowlesSettlement is the settlement in m at which the ultimate bearing pressure is assumed to mobilize (Bowles,
+// 1996)
+const bowlesSettlement = 0.025
+
+// plateWidth is the width of the standard plate load test in m
+const plateWidth = 0.3
+
+// SubgradeModulusField is a struct that contains the modulus of subgrade reaction at grid points of the footprint
+type SubgradeModulusField struct {
+	X  []float64   `json:"x"`
+	Y  []float64   `json:"y"`
+	Ks [][]float64 `json:"ks"`
+}
+
+// calcAverageElasticProperties returns the thickness weighted elastic modulus and poisson ratio of the layers
+// within a depth of 2B below the foundation base. The layers without an elastic modulus or poisson ratio are
+// skipped and NaN is returned when no layer has both.
+func calcAverageElasticProperties(sp ds.SoilProfile, bd ds.BuildingData) (float64, float64) {
+	bottom := math.Min(bd.Df+2*bd.B, sp.GetLayerDepths()[len(sp.Thickness)-1])
+	centers, thicknesses := settlement.GetSublayers(sp, bd.Df, bottom, settlement.SublayerThickness)
+
+	var Es, nu, H float64
+	for i, center := range centers {
+		layerIndex := sp.GetLayerIndex(center)
+		if len(sp.ElasticModulus) <= layerIndex || len(sp.PoissonRatio) <= layerIndex {
+			continue
+		}
+		Es += sp.ElasticModulus[layerIndex] * thicknesses[i]
+		nu += sp.PoissonRatio[layerIndex] * thicknesses[i]
+		H += thicknesses[i]
+	}
+	if H == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return Es / H, nu / H
+}
+
+// CalcVesic returns the modulus of subgrade reaction by Vesic (1961) for the given flexural rigidity of the
+// foundation. The twelfth root term is taken as 1 when the rigidity is not positive.
+func CalcVesic(sp ds.SoilProfile, bd ds.BuildingData, EfIf float64) float64 {
+	Es, nu := calcAverageElasticProperties(sp, bd)
+	rigidityTerm := 1.0
+	if EfIf > 0 {
+		rigidityTerm = math.Pow(Es*math.Pow(bd.B, 4)/EfIf, 1.0/12)
+	}
+	return 0.65 * rigidityTerm * Es / (1 - math.Pow(nu, 2)) / bd.B
+}
+
+// CalcBowles returns the modulus of subgrade reaction from the allowable bearing pressure and its safety factor in
+// the units of the soil profile
+func CalcBowles(sp ds.SoilProfile, qa, FS float64) float64 {
+	return FS * qa / ds.ConvertLength(bowlesSettlement, "m", sp.LengthUnit())
+}
+
+// CalcTerzaghi returns the modulus of subgrade reaction of the foundation by the size correction of Terzaghi (1955)
+// applied to the modulus obtained from a 0.3 m plate load test. A foundation without a positive length is taken as a
+// strip footing.
+func CalcTerzaghi(sp ds.SoilProfile, bd ds.BuildingData, plateModulus float64) float64 {
+	B := bd.B
+	Bp := ds.ConvertLength(plateWidth, "m", sp.LengthUnit())
+	if sp.IsCohesive(bd.Df + 1e-6) {
+		shapeFactor := 2.0 / 3
+		if bd.L > 0 {
+			ratio := bd.L / B
+			shapeFactor = (ratio + 0.5) / (1.5 * ratio)
+		}
+		return plateModulus * (Bp / B) * shapeFactor
+	}
+	return plateModulus * math.Pow((B+Bp)/(2*B), 2)
+}
+
+// CalcFromSettlement returns the modulus of subgrade reaction as the ratio of the foundation pressure to the total
+// settlement at the center of the foundation
+func CalcFromSettlement(sp ds.SoilProfile, bd ds.BuildingData) float64 {
+	return bd.Q / settlement.CalcTotalSettlement(sp, bd)
+}
+
+// CalcSubgradeModulusField returns the modulus of subgrade reaction on a nx by ny grid over the footprint from the
+// settlement of a flexible foundation, which gives stiffer springs at the edges than at the center
+func CalcSubgradeModulusField(sp ds.SoilProfile, bd ds.BuildingData, nx, ny int) SubgradeModulusField {
+	var field SubgradeModulusField
+	for i := 0; i < nx; i++ {
+		field.X = append(field.X, bd.B*(float64(i)+0.5)/float64(nx))
+	}
+	for j := 0; j < ny; j++ {
+		field.Y = append(field.Y, bd.L*(float64(j)+0.5)/float64(ny))
+	}
+
+	for _, x := range field.X {
+		var row []float64
+		for _, y := range field.Y {
+			s := settlement.CalcElasticSettlementAtPoint(sp, bd, x, y) +
+				settlement.CalcConsolidationSettlementAtPoint(sp, bd, x, y)
+			row = append(row, bd.Q/s)
+		}
+		field.Ks = append(field.Ks, row)
+	}
+	return field
+}
@@ -0,0 +1,96 @@
+package subgrade_modulus
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	ds "github.com/geoport/GeotechnicalSubroutines/data_structures"
+	np "github.com/geoport/numpy4go/vectors"
+)
+
+var soilProfile = ds.SoilProfile{
+	SoilClass:           []string{"SP", "CL"},
+	Thickness:           []float64{4, 6},
+	DryUnitWeight:       []float64{18, 17},
+	SaturatedUnitWeight: []float64{20, 19},
+	ElasticModulus:      []float64{20000, 8000},
+	PoissonRatio:        []float64{0.3, 0.4},
+	Cc:                  []float64{0, 0.3},
+	VoidRatio:           []float64{0.6, 0.9},
+	Gwt:                 20,
+	CheckGwt:            true,
+}
+
+var buildingData = ds.BuildingData{
+	Df: 1,
+	B:  2,
+	L:  3,
+	Q:  100,
+}
+
+func TestSubgradeModulus(t *testing.T) {
+	expected := []float64{6177.5, 6714.7, 18000, 13225, 1937.38}
+	output := np.Round([]float64{
+		CalcVesic(soilProfile, buildingData, 0),
+		CalcVesic(soilProfile, buildingData, 1e5),
+		CalcBowles(soilProfile, 150, 3),
+		CalcTerzaghi(soilProfile, buildingData, 40000),
+		CalcFromSettlement(soilProfile, buildingData),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSubgradeModulus_Imperial(t *testing.T) {
+	sp := soilProfile.ConvertUnits("pcf", "psf")
+	bd := buildingData.ConvertUnits("kPa", "psf")
+	// the moduli of subgrade reaction are converted from kN/m3 to pcf
+	factor := ds.ConvertUnitWeight(1, "kN/m3", "pcf")
+
+	expected := []float64{18000, 13225}
+	output := np.Round([]float64{
+		CalcBowles(sp, ds.ConvertPressure(150, "kPa", "psf"), 3) / factor,
+		CalcTerzaghi(sp, bd, 40000*factor) / factor,
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSubgradeModulus_EdgeCases(t *testing.T) {
+	sp := soilProfile
+	sp.ElasticModulus = []float64{20000}
+	cohesive := buildingData
+	cohesive.Df = 5
+	strip := cohesive
+	strip.L = 0
+
+	expected := []float64{7142.86, 5333.33, 4000}
+	output := np.Round([]float64{
+		CalcVesic(sp, buildingData, 0),
+		CalcTerzaghi(soilProfile, cohesive, 40000),
+		CalcTerzaghi(soilProfile, strip, 40000),
+	}, 2)
+	if reflect.DeepEqual(output, expected) == false {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+
+	sp.ElasticModulus = nil
+	if Es, nu := calcAverageElasticProperties(sp, buildingData); !math.IsNaN(Es) || !math.IsNaN(nu) {
+		t.Errorf("Expected NaN, got %v %v", Es, nu)
+	}
+}
+
+func TestCalcSubgradeModulusField(t *testing.T) {
+	expectedX := []float64{0.33, 1, 1.67}
+	expectedCenterRow := []float64{2131.64, 1937.38, 2131.64}
+	output := CalcSubgradeModulusField(soilProfile, buildingData, 3, 3)
+	if reflect.DeepEqual(np.Round(output.X, 2), expectedX) == false {
+		t.Errorf("Expected %v, got %v", expectedX, output.X)
+	}
+	if reflect.DeepEqual(np.Round(output.Ks[1], 2), expectedCenterRow) == false {
+		t.Errorf("Expected %v, got %v", expectedCenterRow, output.Ks[1])
+	}
+}